@@ -0,0 +1,127 @@
+package conust
+
+import "math/big"
+
+// defaultBigFloatPrec is the precision used by DecodeBigFloat when none is requested.
+const defaultBigFloatPrec = 64
+
+// EncodeBigInt turns the input integer into a Conust token using the digits of the given base,
+// which must be between 2 and 36. The output is the same as the output of EncodeToken for input.Text(base).
+// Encoding fails for a nil input or an invalid base.
+func (c *Codec) EncodeBigInt(input *big.Int, base int) (out string, ok bool) {
+	if input == nil || base < minBase || base > maxBase {
+		return "", false
+	}
+	if input.Sign() == 0 {
+		return zeroOutput, true
+	}
+
+	c.scratch = input.Append(c.emptyScratch(), base)
+	digits := c.scratch
+	positive := input.Sign() > 0
+	if !positive {
+		digits = digits[1:]
+	}
+	magnitude := len(digits)
+	for digits[len(digits)-1] == digit0 {
+		digits = digits[:len(digits)-1]
+	}
+
+	return c.writeToken(positive, true, magnitude, digits), true
+}
+
+// DecodeBigInt turns a Conust token back into an integer, interpreting its digits in the given base.
+// A *SyntaxError is returned if the token is invalid or contains digits outside of the base, and ErrFraction
+// if the value has a fractional part.
+func (c *Codec) DecodeBigInt(input string, base int) (out *big.Int, err error) {
+	if base < minBase || base > maxBase {
+		return nil, ErrBase
+	}
+	if input == zeroOutput {
+		return new(big.Int), nil
+	}
+
+	t, err := c.parseToken(input)
+	if err != nil {
+		return nil, err
+	}
+	significantPartLength := t.sEndPos - t.sStartPos
+	if !t.magnitudePositive || significantPartLength > t.magnitude {
+		return nil, ErrFraction
+	}
+
+	c.scratch = c.emptyScratch()
+	if !t.positive {
+		c.scratch = append(c.scratch, minusByte)
+	}
+	for i := t.sStartPos; i < t.sEndPos; i++ {
+		digit := input[i]
+		if !t.positive {
+			digit = reverseDigit(digit)
+		}
+		if digitToInt(digit) >= base {
+			return nil, newSyntaxError(input, i, ReasonDigitOutOfBase)
+		}
+		c.scratch = append(c.scratch, digit)
+	}
+	for i := significantPartLength; i < t.magnitude; i++ {
+		c.scratch = append(c.scratch, digit0)
+	}
+
+	out, ok := new(big.Int).SetString(string(c.scratch), base)
+	if !ok {
+		return nil, ErrSyntax
+	}
+	return out, nil
+}
+
+// EncodeBigFloat turns the input float into a Conust token holding at most sigDigits significant decimal digits.
+// If the float has more significant digits it is rounded to the nearest value, with ties rounded to even.
+// If sigDigits is not positive, the least number of digits is used that is needed to restore the value
+// at the precision of the input. The magnitude of the token is derived from the exponent of the float,
+// so large exponents do not result in long intermediate strings.
+// Encoding fails for a nil input and infinite values.
+func (c *Codec) EncodeBigFloat(input *big.Float, sigDigits int) (out string, ok bool) {
+	if input == nil || input.IsInf() {
+		return "", false
+	}
+	if input.Sign() == 0 {
+		return zeroOutput, true
+	}
+
+	if sigDigits <= 0 {
+		sigDigits = -1
+	} else {
+		// the 'e' format has one digit before the decimal point
+		sigDigits--
+	}
+	c.scratch = input.Append(c.emptyScratch(), exponentByte, sigDigits)
+	return c.encodeExponentFormat(c.scratch), true
+}
+
+// DecodeBigFloat turns a Conust token back into a float of the given precision in bits.
+// The value is rounded to the nearest float of that precision with ties rounded to even.
+// If prec is 0, a precision of 64 is used. The token must hold a decimal number.
+func (c *Codec) DecodeBigFloat(input string, prec uint) (out *big.Float, err error) {
+	if prec == 0 {
+		prec = defaultBigFloatPrec
+	}
+	if input == zeroOutput {
+		return new(big.Float).SetPrec(prec), nil
+	}
+
+	t, err := c.parseToken(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.writeExponentFormat(input, t); err != nil {
+		return nil, err
+	}
+
+	out, _, err = new(big.Float).SetPrec(prec).Parse(string(c.buf), 10)
+	if err != nil {
+		return nil, ErrSyntax
+	}
+	return out, nil
+}
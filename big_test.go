@@ -0,0 +1,181 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestCodec_BigInt(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+		base  int
+	}{
+		{name: "zero", input: "0", base: 10},
+		{name: "one", input: "1", base: 10},
+		{name: "negative one", input: "-1", base: 10},
+		{name: "trailing zeros", input: "123000", base: 10},
+		{name: "negative trailing zeros", input: "-123000", base: 10},
+		{name: "beyond uint64", input: "123456789012345678901234567890123456789012345678901234567890", base: 10},
+		{name: "negative beyond uint64", input: "-123456789012345678901234567890123456789012345678901234567890", base: 10},
+		{name: "binary", input: "-101100000000", base: 2},
+		{name: "hex", input: "ff00ff00ff00ff00ff00ff00ff00ff00", base: 16},
+		{name: "base 36", input: "-cowboyhat", base: 36},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			input, _ := new(big.Int).SetString(i.input, i.base)
+			expected, _ := codec.EncodeToken(i.input)
+			encoded, ok := codec.EncodeBigInt(input, i.base)
+
+			if !ok {
+				t.Fatalf("Encoding failed for: %v\n", i.input)
+			}
+
+			if encoded != expected {
+				t.Fatalf("Encoding expected: %v, got %v\n", expected, encoded)
+			}
+
+			decoded, err := codec.DecodeBigInt(encoded, i.base)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if decoded.Cmp(input) != 0 {
+				t.Fatalf("Decoding expected: %v, got %v\n", input, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_EncodeBigInt_Failure(t *testing.T) {
+	codec := new(Codec)
+	if encoded, ok := codec.EncodeBigInt(nil, 10); ok || encoded != "" {
+		t.Fatal("Encoding should have failed for nil")
+	}
+	if encoded, ok := codec.EncodeBigInt(big.NewInt(1), 1); ok || encoded != "" {
+		t.Fatal("Encoding should have failed for base 1")
+	}
+	if encoded, ok := codec.EncodeBigInt(big.NewInt(1), 37); ok || encoded != "" {
+		t.Fatal("Encoding should have failed for base 37")
+	}
+}
+
+func TestCodec_DecodeBigInt_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+		base  int
+		err   error
+	}{
+		{name: "invalid base", input: "711", base: 37, err: ErrBase},
+		{name: "empty", input: "", base: 10, err: ErrSyntax},
+		{name: "no negative terminator", input: "3xyx", base: 10, err: ErrSyntax},
+		{name: "digit outside base", input: "7212", base: 2, err: ErrSyntax},
+		{name: "negative digit outside base", input: "3xyx~", base: 2, err: ErrSyntax},
+		{name: "fraction", input: "7112", base: 10, err: ErrFraction},
+		{name: "small fraction", input: "6z12", base: 10, err: ErrFraction},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeBigInt(i.input, i.base)
+
+			if !errors.Is(err, i.err) || decoded != nil {
+				t.Fatalf("Decoding expected error %v, got %v, %v\n", i.err, decoded, err)
+			}
+		})
+	}
+}
+
+func TestCodec_BigFloat(t *testing.T) {
+	codecTests := []struct {
+		name      string
+		input     string
+		sigDigits int
+		encoded   string
+	}{
+		{name: "zero", input: "0", sigDigits: 5, encoded: "5"},
+		{name: "integer", input: "1234", sigDigits: -1, encoded: "741234"},
+		{name: "fraction", input: "-0.0012", sigDigits: 0, encoded: "42yx~"},
+		{name: "rounded", input: "123456", sigDigits: 3, encoded: "76123"},
+		{name: "rounded up", input: "0.66666666666666666666", sigDigits: 5, encoded: "6z66667"},
+		{name: "tie to even down", input: "2.5", sigDigits: 1, encoded: "712"},
+		{name: "tie to even up", input: "-3.5", sigDigits: 1, encoded: "3yv~"},
+		{name: "rounded to next magnitude", input: "9.99", sigDigits: 2, encoded: "721"},
+		{name: "huge", input: "1.5e1000", sigDigits: -1, encoded: "7zzzzzzzzzzzzzzzzzzzzzzzzzzzzzf15"},
+		{name: "tiny", input: "-1.5e-1000", sigDigits: -1, encoded: "4zzzzzzzzzzzzzzzzzzzzzzzzzzzzzdyu~"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			input, _, err := big.ParseFloat(i.input, 10, 256, big.ToNearestEven)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, ok := codec.EncodeBigFloat(input, i.sigDigits)
+
+			if !ok {
+				t.Fatalf("Encoding failed for: %v\n", i.input)
+			}
+
+			if encoded != i.encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			if i.sigDigits > 0 {
+				return
+			}
+
+			decoded, err := codec.DecodeBigFloat(encoded, input.Prec())
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if decoded.Cmp(input) != 0 {
+				t.Fatalf("Decoding expected: %v, got %v\n", input, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeBigFloat(t *testing.T) {
+	codec := new(Codec)
+
+	decoded, err := codec.DecodeBigFloat("6z1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Prec() != defaultBigFloatPrec {
+		t.Fatalf("Precision expected: %v, got %v\n", defaultBigFloatPrec, decoded.Prec())
+	}
+	expected, _ := new(big.Float).SetPrec(defaultBigFloatPrec).SetString("0.1")
+	if decoded.Cmp(expected) != 0 {
+		t.Fatalf("Decoding expected: %v, got %v\n", expected, decoded)
+	}
+
+	for _, input := range []string{"", "2z412", "721a", "3xyx"} {
+		if decoded, err := codec.DecodeBigFloat(input, 100); !errors.Is(err, ErrSyntax) || decoded != nil {
+			t.Fatalf("Decoding should have failed for: %v\n", input)
+		}
+	}
+}
+
+func ExampleCodec_EncodeBigInt() {
+	c := new(Codec)
+
+	n, _ := new(big.Int).SetString("-12000000000000000000000000000000000000", 10)
+	out, ok := c.EncodeBigInt(n, 10)
+	fmt.Printf("%q, %v\n", out, ok)
+
+	// Output:
+	// "30vyx~", true
+}
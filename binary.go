@@ -0,0 +1,349 @@
+package conust
+
+// The binary tokens start with a sign byte of the same meaning as the text tokens.
+const (
+	binarySignNegativeMagPositive byte = 0x01
+	binarySignNegativeMagNegative byte = 0x02
+	binaryZero                    byte = 0x03
+	binarySignPositiveMagNegative byte = 0x04
+	binarySignPositiveMagPositive byte = 0x05
+)
+
+// binaryLayout describes how the significant digits are packed in a binary token.
+type binaryLayout struct {
+	// digitBits is the number of bits a digit takes
+	digitBits int
+	// maxDigitValue is the largest digit value of the base of the layout
+	maxDigitValue int
+}
+
+// base36Layout packs the digits of any base on 6 bits, decimalLayout packs decimal digits on 4 bits, like BCD.
+var (
+	base36Layout  = binaryLayout{digitBits: 6, maxDigitValue: maxDigitValue}
+	decimalLayout = binaryLayout{digitBits: 4, maxDigitValue: 9}
+)
+
+// binaryNegativeTerminator closes the binary tokens of negative numbers. The padding of negative numbers
+// is made of ones, which is greater than the bits of any digit at the same position, since the digit values
+// are at most 35 (0b100011) in 6 bits and 9 (0b1001) in 4 bits. So the terminator is only compared to a byte
+// starting with a digit, when the digits of the shorter token end at a byte boundary, and such a byte is
+// at most 0x8f in the 6 bit and 0x9f in the 4 bit layout.
+const binaryNegativeTerminator byte = 0xff
+
+// maxBinaryMagnitude is the largest magnitude of a binary token. It keeps a few bytes long key from
+// claiming a magnitude whose text token would take a huge amount of memory.
+const maxBinaryMagnitude = 1 << 20
+
+// maxBinaryMagnitudeBytes is the largest number of bytes the magnitude of a binary token is written on.
+const maxBinaryMagnitudeBytes = 3
+
+// EncodeBinary works like EncodeTokenErr, but it creates a binary token, which is ordered by bytes.Compare
+// the same way as the numbers are, and which is shorter than the text token. It is meant for key-value stores
+// comparing raw byte keys. The magnitude of the number, that is the number of its integer digits or of the
+// leading zeros of its fraction, can be at most 1048576.
+//
+// The binary token has the same layout as the text token: a sign byte, the magnitude written as its length
+// in bytes followed by its big endian bytes, and the significant digits packed on 6 bits each. The magnitude
+// and the digits are inverted the same way as in the text token, and negative numbers end with a 0xff byte.
+// Since 6 bits hold the digits of any base up to 36, tokens of every base share this layout.
+// EncodeBinaryDecimal creates shorter tokens for decimal numbers.
+func (c *Codec) EncodeBinary(input string) (out []byte, err error) {
+	token, err := c.EncodeTokenErr(input)
+	if err != nil {
+		return nil, err
+	}
+	return c.TokenToBinary(token)
+}
+
+// DecodeBinary turns a binary token back into the normal representation of the number, like DecodeToken does.
+// On failure it returns a *SyntaxError describing the problem.
+func (c *Codec) DecodeBinary(input []byte) (out string, err error) {
+	token, err := c.BinaryToToken(input)
+	if err != nil {
+		return "", err
+	}
+	return c.DecodeTokenErr(token)
+}
+
+// EncodeBinaryDecimal works like EncodeBinary for decimal numbers, but it packs the digits on 4 bits each,
+// so the tokens are a third shorter. These tokens are only ordered among each other, not among the tokens
+// of EncodeBinary.
+func (c *Codec) EncodeBinaryDecimal(input string) (out []byte, err error) {
+	token, err := c.EncodeTokenBase(input, 10)
+	if err != nil {
+		return nil, err
+	}
+	return c.tokenToBinary(token, decimalLayout)
+}
+
+// DecodeBinaryDecimal turns a token created by EncodeBinaryDecimal back into the normal representation
+// of the number. On failure it returns a *SyntaxError describing the problem.
+func (c *Codec) DecodeBinaryDecimal(input []byte) (out string, err error) {
+	token, err := c.binaryToToken(input, decimalLayout)
+	if err != nil {
+		return "", err
+	}
+	return c.DecodeTokenErr(token)
+}
+
+// TokenToBinary turns a text token into the binary token of the same value. Non-canonical tokens
+// are canonicalized first, since the order of the binary tokens depends on it.
+// On failure it returns a *SyntaxError describing the problem of the text token.
+func (c *Codec) TokenToBinary(token string) (out []byte, err error) {
+	return c.tokenToBinary(token, base36Layout)
+}
+
+// TokenToBinaryDecimal works like TokenToBinary, but it creates the binary token of EncodeBinaryDecimal.
+// The token must hold a decimal number.
+func (c *Codec) TokenToBinaryDecimal(token string) (out []byte, err error) {
+	return c.tokenToBinary(token, decimalLayout)
+}
+
+// BinaryToToken turns a binary token back into the text token of the same value.
+// On failure it returns a *SyntaxError describing the problem of the binary token.
+func (c *Codec) BinaryToToken(input []byte) (out string, err error) {
+	return c.binaryToToken(input, base36Layout)
+}
+
+// BinaryDecimalToToken works like BinaryToToken for the binary tokens of EncodeBinaryDecimal.
+func (c *Codec) BinaryDecimalToToken(input []byte) (out string, err error) {
+	return c.binaryToToken(input, decimalLayout)
+}
+
+func (c *Codec) tokenToBinary(token string, layout binaryLayout) (out []byte, err error) {
+	token, err = c.Canonicalize(token)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return []byte{}, nil
+	}
+	if token == zeroOutput {
+		return []byte{binaryZero}, nil
+	}
+
+	t, _ := c.parseToken(token)
+	if t.magnitude > maxBinaryMagnitude {
+		return nil, newSyntaxError(token, 1, ReasonInvalidMagnitude)
+	}
+	digitCount := t.sEndPos - t.sStartPos
+	out = make([]byte, 0, 2+maxBinaryMagnitudeBytes+(digitCount*layout.digitBits+7)/8)
+
+	out = append(out, c.encodeBinarySign(t.positive, t.magnitudePositive))
+	out = appendBinaryMagnitude(out, uint64(t.magnitude), t.positive != t.magnitudePositive)
+
+	// the digits of negative numbers are already reversed in the token, but from the largest digit of base 36
+	reverseShift := 0
+	if !t.positive {
+		reverseShift = maxDigitValue - layout.maxDigitValue
+	}
+	var bits uint
+	var bitCount uint
+	for i := t.sStartPos; i < t.sEndPos; i++ {
+		value := digitToInt(token[i]) - reverseShift
+		if value < 0 || value > layout.maxDigitValue {
+			return nil, newSyntaxError(token, i, ReasonDigitOutOfBase)
+		}
+		bits = bits<<layout.digitBits | uint(value)
+		bitCount += uint(layout.digitBits)
+		if bitCount >= 8 {
+			bitCount -= 8
+			out = append(out, byte(bits>>bitCount))
+			bits &= 1<<bitCount - 1
+		}
+	}
+	if bitCount > 0 {
+		padding := 8 - bitCount
+		last := byte(bits << padding)
+		if !t.positive {
+			last |= 1<<padding - 1
+		}
+		out = append(out, last)
+	}
+
+	if !t.positive {
+		out = append(out, binaryNegativeTerminator)
+	}
+	return out, nil
+}
+
+func (c *Codec) binaryToToken(input []byte, layout binaryLayout) (out string, err error) {
+	in := bytesToString(input)
+	if in == "" {
+		return "", nil
+	}
+	if in[0] == binaryZero {
+		if len(in) > 1 {
+			return "", newSyntaxError(in, 1, ReasonInvalidCharacter)
+		}
+		return zeroOutput, nil
+	}
+
+	positive, magnitudePositive, ok := c.decodeBinarySign(in[0])
+	if !ok {
+		return "", newSyntaxError(in, 0, ReasonInvalidSign)
+	}
+
+	magnitude, pos, err := decodeBinaryMagnitude(in, positive != magnitudePositive)
+	if err != nil {
+		return "", err
+	}
+	if magnitudePositive && magnitude == 0 {
+		return "", newSyntaxError(in, 1, ReasonNonCanonicalMagnitude)
+	}
+
+	end := len(in)
+	if !positive {
+		if in[end-1] != binaryNegativeTerminator {
+			return "", newSyntaxError(in, end-1, ReasonMissingTerminator)
+		}
+		end--
+	}
+	if end <= pos {
+		return "", newSyntaxError(in, end, ReasonUnexpectedEnd)
+	}
+
+	// a zero digit is stored as a zero value for positive and as the largest value for negative numbers,
+	// while the padding is made of zeros for positive and of ones for negative numbers
+	zeroDigitValue := 0
+	paddingGroup := uint(0)
+	reverseShift := 0
+	if !positive {
+		zeroDigitValue = layout.maxDigitValue
+		paddingGroup = 1<<layout.digitBits - 1
+		reverseShift = maxDigitValue - layout.maxDigitValue
+	}
+
+	// the padding can be as long as a digit, but it is never a valid last digit
+	digitCount := (end - pos) * 8 / layout.digitBits
+	if digitCount > 0 && readBits(in[pos:end], (digitCount-1)*layout.digitBits, layout.digitBits) == paddingGroup {
+		digitCount--
+	}
+	if digitCount == 0 {
+		return "", newSyntaxError(in, end, ReasonUnexpectedEnd)
+	}
+
+	c.scratch = c.emptyScratch()
+	for i := 0; i < digitCount; i++ {
+		bitPos := i * layout.digitBits
+		offset := pos + bitPos/8
+		value := int(readBits(in[pos:end], bitPos, layout.digitBits))
+		if value > layout.maxDigitValue {
+			return "", newSyntaxError(in, offset, ReasonInvalidCharacter)
+		}
+		if value == zeroDigitValue && i == 0 {
+			return "", newSyntaxError(in, offset, ReasonLeadingZero)
+		}
+		if value == zeroDigitValue && i == digitCount-1 {
+			return "", newSyntaxError(in, offset, ReasonTrailingZero)
+		}
+		c.scratch = append(c.scratch, intToDigit(value+reverseShift))
+	}
+
+	paddingBits := (end-pos)*8 - digitCount*layout.digitBits
+	if paddingBits >= 8 {
+		return "", newSyntaxError(in, end-1, ReasonInvalidCharacter)
+	}
+	padding := readBits(in[pos:end], digitCount*layout.digitBits, paddingBits)
+	if (positive && padding != 0) || (!positive && padding != 1<<paddingBits-1) {
+		return "", newSyntaxError(in, end-1, ReasonInvalidCharacter)
+	}
+
+	c.buf = c.emptyBuf()
+	c.grow(c.calculateEncodedSize(positive, magnitude, 0, digitCount, -1))
+	c.buf = append(c.buf, c.encodeSign(positive, magnitudePositive))
+	c.writeMagnitude(positive, magnitudePositive, magnitude)
+	c.buf = append(c.buf, c.scratch...)
+	if !positive {
+		c.buf = append(c.buf, c.terminatorByte())
+	}
+	return string(c.buf), nil
+}
+
+func (c *Codec) encodeBinarySign(positive bool, magnitudePositive bool) byte {
+	if positive {
+		if magnitudePositive {
+			return binarySignPositiveMagPositive
+		}
+		return binarySignPositiveMagNegative
+	}
+	if magnitudePositive {
+		return binarySignNegativeMagPositive
+	}
+	return binarySignNegativeMagNegative
+}
+
+func (c *Codec) decodeBinarySign(b byte) (positive bool, magnitudePositive bool, ok bool) {
+	switch b {
+	case binarySignPositiveMagPositive:
+		return true, true, true
+	case binarySignPositiveMagNegative:
+		return true, false, true
+	case binarySignNegativeMagNegative:
+		return false, false, true
+	case binarySignNegativeMagPositive:
+		return false, true, true
+	default:
+		return false, false, false
+	}
+}
+
+// appendBinaryMagnitude appends the number of bytes of the magnitude followed by its big endian bytes,
+// so that a longer magnitude sorts after a shorter one. All of them are inverted if reverse is set.
+func appendBinaryMagnitude(dst []byte, magnitude uint64, reverse bool) []byte {
+	length := 0
+	for m := magnitude; m > 0; m >>= 8 {
+		length++
+	}
+
+	var flip byte
+	if reverse {
+		flip = 0xff
+	}
+	dst = append(dst, byte(length)^flip)
+	for i := length - 1; i >= 0; i-- {
+		dst = append(dst, byte(magnitude>>(8*i))^flip)
+	}
+	return dst
+}
+
+// decodeBinaryMagnitude reads the magnitude following the sign byte, and returns the position after it.
+func decodeBinaryMagnitude(in string, reverse bool) (magnitude int, next int, err error) {
+	var flip byte
+	if reverse {
+		flip = 0xff
+	}
+	if len(in) < 2 {
+		return 0, 0, newSyntaxError(in, len(in), ReasonUnexpectedEnd)
+	}
+
+	length := int(in[1] ^ flip)
+	if length > maxBinaryMagnitudeBytes {
+		return 0, 0, newSyntaxError(in, 1, ReasonInvalidMagnitude)
+	}
+	if 2+length > len(in) {
+		return 0, 0, newSyntaxError(in, len(in), ReasonUnexpectedEnd)
+	}
+
+	var m uint64
+	for i := 2; i < 2+length; i++ {
+		m = m<<8 | uint64(in[i]^flip)
+	}
+	if length > 0 && in[2]^flip == 0 {
+		return 0, 0, newSyntaxError(in, 2, ReasonNonCanonicalMagnitude)
+	}
+	if m > maxBinaryMagnitude {
+		return 0, 0, newSyntaxError(in, 2, ReasonInvalidMagnitude)
+	}
+	return int(m), 2 + length, nil
+}
+
+// readBits returns count bits of data starting at the given bit position, with the first bit being the highest.
+func readBits(data string, bitPos int, count int) uint {
+	var value uint
+	for i := bitPos; i < bitPos+count; i++ {
+		bit := data[i/8] >> (7 - i%8) & 1
+		value = value<<1 | uint(bit)
+	}
+	return value
+}
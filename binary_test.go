@@ -0,0 +1,345 @@
+package conust
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCodec_Binary(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		binary  []byte
+		decoded string
+	}{
+		{name: "empty", input: "", binary: []byte{}, decoded: ""},
+		{name: "zero", input: "-0.0", binary: []byte{0x03}, decoded: "0"},
+		{name: "one", input: "1", binary: []byte{0x05, 0x01, 0x01, 0x04}, decoded: "1"},
+		{name: "negative one", input: "-1", binary: []byte{0x01, 0xfe, 0xfe, 0x8b, 0xff}, decoded: "-1"},
+		{name: "half", input: "0.5", binary: []byte{0x04, 0xff, 0x14}, decoded: "0.5"},
+		{name: "negative small", input: "-0.005", binary: []byte{0x02, 0x01, 0x02, 0x7b, 0xff}, decoded: "-0.005"},
+		{name: "three digits", input: "123", binary: []byte{0x05, 0x01, 0x03, 0x04, 0x20, 0xc0}, decoded: "123"},
+		{name: "negative three digits", input: "-123", binary: []byte{0x01, 0xfe, 0xfc, 0x8a, 0x18, 0x3f, 0xff}, decoded: "-123"},
+		{name: "four digits", input: "1234", binary: []byte{0x05, 0x01, 0x04, 0x04, 0x20, 0xc4}, decoded: "1234"},
+		{name: "letters", input: "zz", binary: []byte{0x05, 0x01, 0x02, 0x8e, 0x30}, decoded: "zz"},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			binary, err := c.EncodeBinary(tc.input)
+			if err != nil || !bytes.Equal(binary, tc.binary) {
+				t.Fatalf("EncodeBinary(%q) = %x, %v, expected %x", tc.input, binary, err, tc.binary)
+			}
+			decoded, err := c.DecodeBinary(binary)
+			if err != nil || decoded != tc.decoded {
+				t.Fatalf("DecodeBinary(%x) = %q, %v, expected %q", binary, decoded, err, tc.decoded)
+			}
+
+			token, _ := c.EncodeToken(tc.input)
+			converted, err := c.BinaryToToken(binary)
+			if err != nil || converted != token {
+				t.Errorf("BinaryToToken(%x) = %q, %v, expected %q", binary, converted, err, token)
+			}
+		})
+	}
+}
+
+func TestCodec_Binary_Failure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  []byte
+		reason SyntaxErrorReason
+		offset int
+	}{
+		{name: "invalid sign", input: []byte{0x07, 0x01, 0x01, 0x04}, reason: ReasonInvalidSign, offset: 0},
+		{name: "zero with digits", input: []byte{0x03, 0x04}, reason: ReasonInvalidCharacter, offset: 1},
+		{name: "missing magnitude", input: []byte{0x05}, reason: ReasonUnexpectedEnd, offset: 1},
+		{name: "long magnitude", input: []byte{0x05, 0x04, 0x01}, reason: ReasonInvalidMagnitude, offset: 1},
+		{name: "large magnitude", input: []byte{0x05, 0x03, 0x10, 0x00, 0x01, 0x04}, reason: ReasonInvalidMagnitude, offset: 2},
+		{name: "short magnitude", input: []byte{0x05, 0x02, 0x01}, reason: ReasonUnexpectedEnd, offset: 3},
+		{name: "leading zero magnitude byte", input: []byte{0x05, 0x02, 0x00, 0x01, 0x04}, reason: ReasonNonCanonicalMagnitude, offset: 2},
+		{name: "zero integer digits", input: []byte{0x05, 0x00, 0x04}, reason: ReasonNonCanonicalMagnitude, offset: 1},
+		{name: "missing digits", input: []byte{0x05, 0x01, 0x01}, reason: ReasonUnexpectedEnd, offset: 3},
+		{name: "missing terminator", input: []byte{0x01, 0xfe, 0xfe, 0x8b}, reason: ReasonMissingTerminator, offset: 3},
+		{name: "terminator after long magnitude", input: []byte("\x02\x05,x!\x06\xff"), reason: ReasonInvalidMagnitude, offset: 1},
+		{name: "terminator inside the magnitude", input: []byte("\x02\x03\x01\x00\xff"), reason: ReasonUnexpectedEnd, offset: 4},
+		{name: "terminator right after the magnitude", input: []byte("\x02\x03\x01\x00\x00\xff"), reason: ReasonUnexpectedEnd, offset: 5},
+		{name: "digit out of range", input: []byte{0x05, 0x01, 0x01, 0xfc}, reason: ReasonInvalidCharacter, offset: 3},
+		{name: "leading zero", input: []byte{0x05, 0x01, 0x01, 0x00, 0x40}, reason: ReasonLeadingZero, offset: 3},
+		{name: "trailing zero", input: []byte{0x05, 0x01, 0x01, 0x04, 0x00, 0x00}, reason: ReasonTrailingZero, offset: 4},
+		{name: "padding byte", input: []byte{0x05, 0x01, 0x01, 0x04, 0x00}, reason: ReasonInvalidCharacter, offset: 4},
+		{name: "invalid padding", input: []byte{0x05, 0x01, 0x01, 0x05}, reason: ReasonInvalidCharacter, offset: 3},
+		{name: "invalid negative padding", input: []byte{0x01, 0xfe, 0xfe, 0x88, 0xff}, reason: ReasonInvalidCharacter, offset: 3},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.DecodeBinary(tc.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("DecodeBinary(%x) error = %v, expected a *SyntaxError", tc.input, err)
+			}
+			if syntaxErr.Reason != tc.reason || syntaxErr.Offset != tc.offset {
+				t.Errorf("DecodeBinary(%x) error = %v, expected %v at offset %d", tc.input, err, tc.reason, tc.offset)
+			}
+		})
+	}
+
+	if _, err := c.EncodeBinary(strings.Repeat("1", maxBinaryMagnitude+1)); err == nil {
+		t.Error("EncodeBinary accepted a magnitude above the limit")
+	}
+	if _, err := c.EncodeBinary("1.2.3"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("EncodeBinary error = %v, expected %v", err, ErrSyntax)
+	}
+}
+
+func TestCodec_BinaryToToken_Random(t *testing.T) {
+	// malformed keys must be rejected without panicking
+	r := rand.New(rand.NewSource(1))
+	c := new(Codec)
+	input := make([]byte, 0, 12)
+	for i := 0; i < 100000; i++ {
+		input = input[:r.Intn(cap(input))]
+		r.Read(input)
+		if len(input) > 0 {
+			input[0] = byte(r.Intn(6))
+		}
+		if token, err := c.BinaryToToken(input); err == nil {
+			if _, err := c.DecodeTokenErr(token); err != nil {
+				t.Fatalf("BinaryToToken(%x) = %q, which can not be decoded: %v", input, token, err)
+			}
+		}
+		if token, err := c.BinaryDecimalToToken(input); err == nil {
+			if _, err := c.DecodeTokenBase(token, 10); err != nil {
+				t.Fatalf("BinaryDecimalToToken(%x) = %q, which can not be decoded: %v", input, token, err)
+			}
+		}
+	}
+}
+
+func TestCodec_TokenToBinary_Canonicalizes(t *testing.T) {
+	c := new(Codec)
+	canonical, _ := c.TokenToBinary("711")
+	binary, err := c.TokenToBinary("7110")
+	if err != nil || !bytes.Equal(binary, canonical) {
+		t.Errorf("TokenToBinary(%q) = %x, %v, expected %x", "7110", binary, err, canonical)
+	}
+}
+
+func TestBinarySortedness(t *testing.T) {
+	step := 0.01
+	var prev []byte
+	c := new(Codec)
+	for i := -111111.0; i <= 111111.0; i++ {
+		str := fmt.Sprintf("%3f", i*step)
+		binary, err := c.EncodeBinary(str)
+		if err != nil {
+			t.Fatal("Encoding failed for", i)
+		}
+		if prev != nil && bytes.Compare(prev, binary) >= 0 {
+			t.Fatalf("at %v %x is not smaller than %x", i*step, prev, binary)
+		}
+		prev = binary
+	}
+}
+
+func TestBinarySortedness_Prefixes(t *testing.T) {
+	// the numbers are listed in their expected order, their digits are prefixes of each other
+	numbers := []string{
+		"-1.23456789", "-1.2345678", "-1.234567", "-1.23456", "-1.2345", "-1.234", "-1.23", "-1.2", "-1.1z", "-1.1",
+		"-0.1", "-0.09", "0.09", "0.1", "1.1", "1.1z", "1.2", "1.23", "1.234", "1.2345", "1.23456", "1.234567", "1.2345678",
+	}
+
+	c := new(Codec)
+	var prev []byte
+	for _, number := range numbers {
+		binary, err := c.EncodeBinary(number)
+		if err != nil {
+			t.Fatal("Encoding failed for", number)
+		}
+		if prev != nil && bytes.Compare(prev, binary) >= 0 {
+			t.Fatalf("at %v %x is not smaller than %x", number, prev, binary)
+		}
+		prev = binary
+	}
+}
+
+func TestBinarySortedness_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	c := new(Codec)
+	for i := 0; i < 20000; i++ {
+		a := r.NormFloat64() * float64(r.Intn(1000000))
+		b := r.NormFloat64() * float64(r.Intn(1000000))
+		aBinary, _ := c.EncodeBinary(strconv.FormatFloat(a, 'f', -1, 64))
+		bBinary, _ := c.EncodeBinary(strconv.FormatFloat(b, 'f', -1, 64))
+
+		expected := 0
+		if a < b {
+			expected = -1
+		} else if a > b {
+			expected = 1
+		}
+		if got := bytes.Compare(aBinary, bBinary); got != expected {
+			t.Fatalf("%v and %v compare as %d, expected %d (%x, %x)", a, b, got, expected, aBinary, bBinary)
+		}
+	}
+}
+
+func TestCodec_BinaryDecimal(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		binary []byte
+	}{
+		{name: "zero", input: "0", binary: []byte{0x03}},
+		{name: "one", input: "1", binary: []byte{0x05, 0x01, 0x01, 0x10}},
+		{name: "negative one", input: "-1", binary: []byte{0x01, 0xfe, 0xfe, 0x8f, 0xff}},
+		{name: "half", input: "0.5", binary: []byte{0x04, 0xff, 0x50}},
+		{name: "negative small", input: "-0.005", binary: []byte{0x02, 0x01, 0x02, 0x4f, 0xff}},
+		{name: "three digits", input: "123", binary: []byte{0x05, 0x01, 0x03, 0x12, 0x30}},
+		{name: "negative three digits", input: "-123", binary: []byte{0x01, 0xfe, 0xfc, 0x87, 0x6f, 0xff}},
+		{name: "four digits", input: "1234", binary: []byte{0x05, 0x01, 0x04, 0x12, 0x34}},
+		{name: "negative four digits", input: "-1234", binary: []byte{0x01, 0xfe, 0xfb, 0x87, 0x65, 0xff}},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			binary, err := c.EncodeBinaryDecimal(tc.input)
+			if err != nil || !bytes.Equal(binary, tc.binary) {
+				t.Fatalf("EncodeBinaryDecimal(%q) = %x, %v, expected %x", tc.input, binary, err, tc.binary)
+			}
+			decoded, err := c.DecodeBinaryDecimal(binary)
+			if err != nil || decoded != tc.input {
+				t.Fatalf("DecodeBinaryDecimal(%x) = %q, %v, expected %q", binary, decoded, err, tc.input)
+			}
+
+			token, _ := c.EncodeToken(tc.input)
+			converted, err := c.BinaryDecimalToToken(binary)
+			if err != nil || converted != token {
+				t.Errorf("BinaryDecimalToToken(%x) = %q, %v, expected %q", binary, converted, err, token)
+			}
+		})
+	}
+}
+
+func TestCodec_BinaryDecimal_Failure(t *testing.T) {
+	c := new(Codec)
+	if _, err := c.EncodeBinaryDecimal("ff"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("EncodeBinaryDecimal(%q) error = %v, expected %v", "ff", err, ErrSyntax)
+	}
+
+	tokens := []struct {
+		token  string
+		offset int
+	}{
+		{token: "72ff", offset: 2},
+		{token: "3xu0~", offset: 3},
+	}
+	for _, tc := range tokens {
+		_, err := c.TokenToBinaryDecimal(tc.token)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Reason != ReasonDigitOutOfBase || syntaxErr.Offset != tc.offset {
+			t.Errorf("TokenToBinaryDecimal(%q) error = %v, expected %v at offset %d", tc.token, err, ReasonDigitOutOfBase, tc.offset)
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		input  []byte
+		reason SyntaxErrorReason
+		offset int
+	}{
+		{name: "digit out of base", input: []byte{0x05, 0x01, 0x01, 0xa0}, reason: ReasonInvalidCharacter, offset: 3},
+		{name: "leading zero", input: []byte{0x05, 0x01, 0x02, 0x01}, reason: ReasonLeadingZero, offset: 3},
+		{name: "trailing zero", input: []byte{0x05, 0x01, 0x02, 0x10, 0x00}, reason: ReasonTrailingZero, offset: 4},
+		{name: "negative trailing zero", input: []byte{0x01, 0xfe, 0xfe, 0x89, 0xff}, reason: ReasonTrailingZero, offset: 3},
+		{name: "no digits", input: []byte{0x05, 0x01, 0x01}, reason: ReasonUnexpectedEnd, offset: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.BinaryDecimalToToken(tc.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("BinaryDecimalToToken(%x) error = %v, expected a *SyntaxError", tc.input, err)
+			}
+			if syntaxErr.Reason != tc.reason || syntaxErr.Offset != tc.offset {
+				t.Errorf("BinaryDecimalToToken(%x) error = %v, expected %v at offset %d", tc.input, err, tc.reason, tc.offset)
+			}
+		})
+	}
+}
+
+func TestBinaryDecimalSortedness(t *testing.T) {
+	// the numbers are listed in their expected order, their digits are prefixes of each other
+	numbers := []string{
+		"-1.23456789", "-1.2345678", "-1.234567", "-1.23456", "-1.2345", "-1.234", "-1.23", "-1.2", "-1.19", "-1.1",
+		"-0.1", "-0.09", "0.09", "0.1", "1.1", "1.19", "1.2", "1.23", "1.234", "1.2345", "1.23456", "1.234567", "1.2345678",
+	}
+
+	c := new(Codec)
+	var prev []byte
+	for _, number := range numbers {
+		binary, err := c.EncodeBinaryDecimal(number)
+		if err != nil {
+			t.Fatal("Encoding failed for", number)
+		}
+		if prev != nil && bytes.Compare(prev, binary) >= 0 {
+			t.Fatalf("at %v %x is not smaller than %x", number, prev, binary)
+		}
+		prev = binary
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		a := r.NormFloat64() * float64(r.Intn(1000000))
+		b := r.NormFloat64() * float64(r.Intn(1000000))
+		aBinary, _ := c.EncodeBinaryDecimal(strconv.FormatFloat(a, 'f', -1, 64))
+		bBinary, _ := c.EncodeBinaryDecimal(strconv.FormatFloat(b, 'f', -1, 64))
+
+		expected := 0
+		if a < b {
+			expected = -1
+		} else if a > b {
+			expected = 1
+		}
+		if got := bytes.Compare(aBinary, bBinary); got != expected {
+			t.Fatalf("%v and %v compare as %d, expected %d (%x, %x)", a, b, got, expected, aBinary, bBinary)
+		}
+	}
+}
+
+func ExampleCodec_EncodeBinary() {
+	c := new(Codec)
+
+	binary, err := c.EncodeBinary("-3.14")
+	fmt.Printf("%x, %v\n", binary, err)
+
+	out, err := c.DecodeBinary(binary)
+	fmt.Printf("%q, %v\n", out, err)
+
+	// Output:
+	// 01fefe8227ffff, <nil>
+	// "-3.14", <nil>
+}
+
+func ExampleCodec_EncodeBinaryDecimal() {
+	c := new(Codec)
+
+	binary, err := c.EncodeBinaryDecimal("-3.14")
+	fmt.Printf("%x, %v\n", binary, err)
+
+	out, err := c.DecodeBinaryDecimal(binary)
+	fmt.Printf("%q, %v\n", out, err)
+
+	// Output:
+	// 01fefe685fff, <nil>
+	// "-3.14", <nil>
+}
@@ -0,0 +1,90 @@
+package conust
+
+// ValidateToken checks that the input is exactly the token EncodeToken would produce for its value.
+// Tokens accepted by DecodeToken might still hold the same value in different forms, for example "7110"
+// and "711" both decode to 1, so the canonical form is needed when the tokens are compared for equality.
+// The returned *SyntaxError points to the first byte that makes the token invalid or non-canonical.
+func (c *Codec) ValidateToken(input string) error {
+	if input == "" || input == zeroOutput {
+		return nil
+	}
+
+	t, err := c.parseToken(input)
+	if err != nil {
+		return err
+	}
+
+	// the encoder writes the magnitude with as few digits as possible, and a number that
+	// has integer digits has at least one of them
+	magnitudeEnd := t.sStartPos - 1
+	lastMagnitudeDigit := input[magnitudeEnd]
+	if t.positive != t.magnitudePositive {
+		lastMagnitudeDigit = reverseDigit(lastMagnitudeDigit)
+	}
+	if magnitudeEnd > 1 && lastMagnitudeDigit == digit0 {
+		return newSyntaxError(input, magnitudeEnd, ReasonNonCanonicalMagnitude)
+	}
+	if t.magnitudePositive && t.magnitude == 0 {
+		return newSyntaxError(input, magnitudeEnd, ReasonNonCanonicalMagnitude)
+	}
+
+	if c.significantDigit(input, t, t.sStartPos) == digit0 {
+		return newSyntaxError(input, t.sStartPos, ReasonLeadingZero)
+	}
+	if c.significantDigit(input, t, t.sEndPos-1) == digit0 {
+		return newSyntaxError(input, t.sEndPos-1, ReasonTrailingZero)
+	}
+	return nil
+}
+
+// IsCanonical reports whether the input is exactly the token EncodeToken would produce for its value.
+func (c *Codec) IsCanonical(input string) bool {
+	return c.ValidateToken(input) == nil
+}
+
+// Canonicalize rewrites a token accepted by DecodeToken into the canonical form that EncodeToken
+// would produce for its value. On failure it returns a *SyntaxError describing the problem.
+func (c *Codec) Canonicalize(input string) (out string, err error) {
+	if input == "" || input == zeroOutput {
+		return input, nil
+	}
+
+	t, err := c.parseToken(input)
+	if err != nil {
+		return "", err
+	}
+
+	c.scratch = c.emptyScratch()
+	for i := t.sStartPos; i < t.sEndPos; i++ {
+		c.scratch = append(c.scratch, c.significantDigit(input, t, i))
+	}
+
+	integerDigits := t.magnitude
+	if !t.magnitudePositive {
+		integerDigits = -t.magnitude
+	}
+	digits := c.scratch
+	for len(digits) > 0 && digits[0] == digit0 {
+		digits = digits[1:]
+		integerDigits--
+	}
+	for len(digits) > 0 && digits[len(digits)-1] == digit0 {
+		digits = digits[:len(digits)-1]
+	}
+	if len(digits) == 0 {
+		return zeroOutput, nil
+	}
+
+	if integerDigits > 0 {
+		return c.writeToken(t.positive, true, integerDigits, digits), nil
+	}
+	return c.writeToken(t.positive, false, -integerDigits, digits), nil
+}
+
+// significantDigit returns the significant digit of the token at position i in its non reversed form.
+func (c *Codec) significantDigit(input string, t tokenLayout, i int) byte {
+	if t.positive {
+		return input[i]
+	}
+	return reverseDigit(input[i])
+}
@@ -0,0 +1,132 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodec_ValidateToken(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		reason SyntaxErrorReason
+		offset int
+	}{
+		{name: "empty", input: ""},
+		{name: "zero", input: "5"},
+		{name: "one", input: "711"},
+		{name: "negative one", input: "3yy~"},
+		{name: "fraction", input: "6w125"},
+		{name: "negative fraction", input: "42yx~"},
+		{name: "long magnitude", input: "7y1"},
+		{name: "chained magnitude", input: "7z11"},
+		{name: "negative chained magnitude", input: "30yy~"},
+
+		{name: "trailing zero", input: "7110", reason: ReasonTrailingZero, offset: 3},
+		{name: "negative trailing zero", input: "3yyz~", reason: ReasonTrailingZero, offset: 3},
+		{name: "leading zero", input: "7101", reason: ReasonLeadingZero, offset: 2},
+		{name: "negative leading zero", input: "3yzy~", reason: ReasonLeadingZero, offset: 2},
+		{name: "only zeros", input: "7100", reason: ReasonLeadingZero, offset: 2},
+		{name: "zero at the end of the magnitude chain", input: "7z01", reason: ReasonNonCanonicalMagnitude, offset: 2},
+		{name: "zero integer digits", input: "701", reason: ReasonNonCanonicalMagnitude, offset: 1},
+		{name: "negative zero integer digits", input: "3zy~", reason: ReasonNonCanonicalMagnitude, offset: 1},
+
+		{name: "too short", input: "71", reason: ReasonUnexpectedEnd, offset: 2},
+		{name: "missing terminator", input: "3yy", reason: ReasonMissingTerminator, offset: 2},
+		{name: "terminator of positive", input: "711~", reason: ReasonInvalidCharacter, offset: 3},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := c.ValidateToken(tc.input)
+			if tc.reason == 0 {
+				if err != nil {
+					t.Fatalf("ValidateToken(%q) = %v, expected no error", tc.input, err)
+				}
+				if !c.IsCanonical(tc.input) {
+					t.Errorf("IsCanonical(%q) = false", tc.input)
+				}
+				return
+			}
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ValidateToken(%q) = %v, expected a *SyntaxError", tc.input, err)
+			}
+			if syntaxErr.Reason != tc.reason || syntaxErr.Offset != tc.offset {
+				t.Errorf("ValidateToken(%q) = %v, expected %v at offset %d", tc.input, err, tc.reason, tc.offset)
+			}
+			if c.IsCanonical(tc.input) {
+				t.Errorf("IsCanonical(%q) = true", tc.input)
+			}
+		})
+	}
+}
+
+func TestCodec_Canonicalize(t *testing.T) {
+	testCases := []struct {
+		input  string
+		output string
+	}{
+		{input: "", output: ""},
+		{input: "5", output: "5"},
+		{input: "711", output: "711"},
+		{input: "7110", output: "711"},
+		{input: "7101", output: "6z1"},
+		{input: "3yzy~", output: "40y~"},
+		{input: "7100", output: "5"},
+		{input: "7z01", output: "7y1"},
+		{input: "701", output: "6z1"},
+		{input: "3zy~", output: "40y~"},
+		{input: "7300120", output: "7112"},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			out, err := c.Canonicalize(tc.input)
+			if err != nil || out != tc.output {
+				t.Fatalf("Canonicalize(%q) = %q, %v, expected %q", tc.input, out, err, tc.output)
+			}
+			if !c.IsCanonical(out) {
+				t.Errorf("IsCanonical(%q) = false", out)
+			}
+
+			decoded, _ := c.DecodeFloat64(tc.input)
+			if encoded, _ := c.EncodeFloat64(decoded); tc.input != "" && encoded != out {
+				t.Errorf("Canonicalize(%q) = %q, but the encoded value is %q", tc.input, out, encoded)
+			}
+		})
+	}
+
+	if _, err := c.Canonicalize("3yy"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Canonicalize of an invalid token returned %v", err)
+	}
+}
+
+func TestCodec_ValidateToken_Encoded(t *testing.T) {
+	c := new(Codec)
+	for i := -3000; i <= 3000; i += 7 {
+		for _, input := range []string{fmt.Sprint(i), fmt.Sprintf("%.4f", float64(i)/1000)} {
+			encoded, _ := c.EncodeToken(input)
+			if err := c.ValidateToken(encoded); err != nil {
+				t.Fatalf("ValidateToken(%q) of %q = %v", encoded, input, err)
+			}
+		}
+	}
+}
+
+func ExampleCodec_Canonicalize() {
+	c := new(Codec)
+
+	fmt.Println(c.IsCanonical("7110"))
+
+	out, err := c.Canonicalize("7110")
+	fmt.Printf("%q, %v\n", out, err)
+
+	// Output:
+	// false
+	// "711", <nil>
+}
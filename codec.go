@@ -11,8 +11,23 @@ import (
 // There is also EncodeMixedText, a convenience function, that encodes each group of decimal numbers
 // and returns the resulting string. So that for example the strings "Item 20" and "Item 100" become
 // "Item 722" and "Item 731" which sort as the numeric value in them would naturally imply.
+//
+// The zero value is ready to use. A Codec reuses its internal buffers between calls, so it is not safe
+// for concurrent use. It can be copied, and the copy drops the buffers it shares with the original on its
+// first use, so the original and its copies can be used in different goroutines. The package level functions
+// are safe for concurrent use.
+//
+// NewCodec creates a Codec with a different number format, terminator or separator.
 type Codec struct {
-	builder strings.Builder
+	// self detects copies of the Codec, like the addr field of strings.Builder
+	self    *Codec
+	buf     []byte
+	scratch []byte
+
+	// number is nil for the standard number format
+	number     *numberFormat
+	terminator byte
+	separator  byte
 }
 
 // EncodeToken turns the input number into the alphanumerically sortable Conust string.
@@ -22,28 +37,175 @@ type Codec struct {
 // sorting of the string.
 // EncodeMixedText does that automatically
 func (c *Codec) EncodeToken(input string) (out string, ok bool) {
+	out, err := c.EncodeTokenErr(input)
+	return out, err == nil
+}
+
+// EncodeTokenErr works like EncodeToken, but on failure it returns a *SyntaxError describing the problem.
+func (c *Codec) EncodeTokenErr(input string) (out string, err error) {
+	return c.encodeTokenBase(input, maxBase)
+}
+
+// EncodeTokenBase works like EncodeTokenErr, but it only accepts the digits of the given base,
+// which must be between 2 and 36. The returned *SyntaxError holds the position of the first invalid digit.
+func (c *Codec) EncodeTokenBase(input string, base int) (out string, err error) {
+	if base < minBase || base > c.highestBase() {
+		return "", ErrBase
+	}
+	return c.encodeTokenBase(input, base)
+}
+
+func (c *Codec) encodeTokenBase(input string, base int) (out string, err error) {
+	c.buf = c.emptyBuf()
+	if err := c.appendTokenBase(input, base); err != nil {
+		return "", err
+	}
+	return string(c.buf), nil
+}
+
+// AppendToken works like EncodeTokenErr, but it appends the token to dst and returns the extended buffer.
+// If dst has enough capacity, which can be ensured with EncodedLen, no memory is allocated.
+// On failure dst is returned unchanged.
+func (c *Codec) AppendToken(dst []byte, src []byte) ([]byte, error) {
+	// dst must not be dropped as an inherited buffer
+	c.ownBuffers()
+	buf := c.buf
+	c.buf = dst
+	err := c.appendTokenBase(bytesToString(src), maxBase)
+	dst, c.buf = c.buf, buf
+	return dst, err
+}
+
+// EncodedLen returns the length of the token that EncodeToken and AppendToken would create from src.
+func (c *Codec) EncodedLen(src []byte) (n int, err error) {
+	if len(src) == 0 {
+		return 0, nil
+	}
+	input := c.standardInput(bytesToString(src))
+	if err := c.validateInput(input, maxBase); err != nil {
+		return 0, c.restoreSyntaxError(err, bytesToString(src))
+	}
+
+	positive := c.getPositivity(input)
+	decimalPointPos := c.getDecimalPointPos(input)
+	sStartPos := c.getSignificantStartPos(input)
+	sEndPos := c.getSignificantEndPos(input)
+	if sStartPos == sEndPos {
+		return len(zeroOutput), nil
+	}
+
+	magnitude, _ := c.getMagnitudeParams(len(input), sStartPos, sEndPos, decimalPointPos, 0)
+	return c.calculateEncodedSize(positive, magnitude, sStartPos, sEndPos, decimalPointPos), nil
+}
+
+// appendTokenBase validates the input given in the number format of the codec and appends its token
+// to the buffer. The buffer is unchanged on failure.
+func (c *Codec) appendTokenBase(input string, base int) error {
+	if input == "" {
+		return nil
+	}
+
+	err := c.appendStandardTokenBase(c.standardInput(input), base)
+	return c.restoreSyntaxError(err, input)
+}
+
+// appendStandardTokenBase works like appendTokenBase for input in the standard number format.
+func (c *Codec) appendStandardTokenBase(input string, base int) error {
+	if input == "" {
+		return nil
+	}
+
+	if err := c.validateInput(input, base); err != nil {
+		return err
+	}
+
+	c.appendValidInput(input, 0)
+	return nil
+}
+
+// EncodeTokenAnyCase works like EncodeToken, but it also accepts uppercase letter digits, so that for example
+// "FF.8" and "ff.8" result in the same token. For a codec with a custom alphabet both cases of its letters are accepted.
+func (c *Codec) EncodeTokenAnyCase(input string) (out string, ok bool) {
+	if c.number != nil {
+		// the letters are turned into the case used by the alphabet of the codec
+		c.scratch = append(c.emptyScratch(), input...)
+		for i, b := range c.scratch {
+			if c.number.toStandard[b] == 0 && c.number.toStandard[otherCase(b)] != 0 {
+				c.scratch[i] = otherCase(b)
+			}
+		}
+		return c.EncodeToken(string(c.scratch))
+	}
+
+	upperFound := false
+	for i := 0; i < len(input); i++ {
+		if isUpperDigit(input[i]) {
+			upperFound = true
+			break
+		}
+	}
+	if !upperFound {
+		return c.EncodeToken(input)
+	}
+
+	c.scratch = append(c.emptyScratch(), input...)
+	for i, b := range c.scratch {
+		if isUpperDigit(b) {
+			c.scratch[i] = b - digitUpperA + digitA
+		}
+	}
+	return c.EncodeToken(string(c.scratch))
+}
+
+// EncodeScientificToken works like EncodeToken for decimal numbers, but it also accepts numbers in exponent
+// notation like "1.5e-300" or "-2E+10", which is the number format of JSON. The exponent is folded into
+// the magnitude of the token, so large exponents do not result in long intermediate strings.
+// Only decimal digits are accepted, since 'e' would be a digit in bases above 14.
+// The absolute value of the exponent can be at most 10000, so that short untrusted input can not create huge tokens.
+func (c *Codec) EncodeScientificToken(input string) (out string, ok bool) {
 	if input == "" {
 		return "", true
 	}
 
-	if !c.isValidInput(input) {
+	mantissa := input
+	exponent := 0
+	if exponentPos := strings.IndexAny(input, "eE"); exponentPos >= 0 {
+		mantissa = input[:exponentPos]
+		exponent, ok = c.parseExponent(input[exponentPos+1:])
+		if !ok {
+			return "", false
+		}
+	}
+
+	if mantissa == "" {
+		return "", false
+	}
+	mantissa = c.standardInput(mantissa)
+	if !c.isValidDecimalInput(mantissa) {
 		return "", false
 	}
 
+	c.buf = c.emptyBuf()
+	c.appendValidInput(mantissa, exponent)
+	return string(c.buf), true
+}
+
+// appendValidInput appends the token of input * 10^exponent to the buffer, where input has already been validated.
+func (c *Codec) appendValidInput(input string, exponent int) {
 	positive := c.getPositivity(input)
 	decimalPointPos := c.getDecimalPointPos(input)
 	sStartPos := c.getSignificantStartPos(input)
 	sEndPos := c.getSignificantEndPos(input)
 
 	if sStartPos == sEndPos {
-		return zeroOutput, true
+		c.buf = append(c.buf, zeroOutput...)
+		return
 	}
 
-	magnitude, magnitudePositive := c.getMagnitudeParams(len(input), sStartPos, sEndPos, decimalPointPos)
+	magnitude, magnitudePositive := c.getMagnitudeParams(len(input), sStartPos, sEndPos, decimalPointPos, exponent)
 
-	c.builder.Reset()
-	c.builder.Grow(c.calculateEncodedSize(positive, magnitude, sStartPos, sEndPos, decimalPointPos))
-	c.builder.WriteByte(c.encodeSign(positive, magnitudePositive))
+	c.grow(c.calculateEncodedSize(positive, magnitude, sStartPos, sEndPos, decimalPointPos))
+	c.buf = append(c.buf, c.encodeSign(positive, magnitudePositive))
 	c.writeMagnitude(positive, magnitudePositive, magnitude)
 
 	if sStartPos < decimalPointPos && decimalPointPos < sEndPos {
@@ -53,158 +215,267 @@ func (c *Codec) EncodeToken(input string) (out string, ok bool) {
 		c.writeDigits(positive, input[sStartPos:sEndPos])
 	}
 	if !positive {
-		c.builder.WriteByte(negativeNumberTerminator)
+		c.buf = append(c.buf, c.terminatorByte())
 	}
-	return c.builder.String(), true
 }
 
 // DecodeToken turns a Conust string back into its normal representation. The output will not reconstruct
 // leading and trailing zeros. The plus sign for positive numbers is omitted as well.
 func (c *Codec) DecodeToken(input string) (out string, ok bool) {
-	if input == "" {
-		return "", true
+	out, err := c.DecodeTokenErr(input)
+	return out, err == nil
+}
+
+// DecodeTokenErr works like DecodeToken, but on failure it returns a *SyntaxError describing the problem.
+func (c *Codec) DecodeTokenErr(input string) (out string, err error) {
+	return c.decodeTokenBase(input, maxBase)
+}
+
+// DecodeTokenBase works like DecodeTokenErr, but it only accepts tokens holding digits of the given base,
+// which must be between 2 and 36. The returned *SyntaxError holds the position of the first invalid digit.
+func (c *Codec) DecodeTokenBase(input string, base int) (out string, err error) {
+	if base < minBase || base > c.highestBase() {
+		return "", ErrBase
 	}
+	return c.decodeTokenBase(input, base)
+}
 
-	if input == zeroOutput {
-		return zeroInput, true
+func (c *Codec) decodeTokenBase(input string, base int) (out string, err error) {
+	c.buf = c.emptyBuf()
+	if err := c.appendDecodedBase(input, base); err != nil {
+		return "", err
 	}
+	return string(c.buf), nil
+}
 
-	if len(input) < 3 {
-		return "", false
+// AppendDecoded works like DecodeTokenErr, but it appends the decoded number to dst and returns the extended buffer.
+// If dst has enough capacity, which can be ensured with DecodedLen, no memory is allocated.
+// On failure dst is returned unchanged.
+func (c *Codec) AppendDecoded(dst []byte, src []byte) ([]byte, error) {
+	// dst must not be dropped as an inherited buffer
+	c.ownBuffers()
+	buf := c.buf
+	c.buf = dst
+	err := c.appendDecodedBase(bytesToString(src), maxBase)
+	dst, c.buf = c.buf, buf
+	return dst, err
+}
+
+// DecodedLen returns the length of the number that DecodeToken and AppendDecoded would create from src.
+func (c *Codec) DecodedLen(src []byte) (n int, err error) {
+	input := bytesToString(src)
+	if input == "" {
+		return 0, nil
+	}
+	if input == zeroOutput {
+		return len(zeroInput), nil
 	}
 
-	positive, magnitudePositive, ok := c.decodeSigns(input)
-	if !ok {
-		return "", false
+	t, err := c.parseTokenBase(input, maxBase)
+	if err != nil {
+		return 0, err
 	}
+	return c.calculateDecodedLength(t.positive, t.magnitudePositive, t.magnitude, t.sEndPos-t.sStartPos), nil
+}
 
-	magnitude, sStartPos, ok := c.decodeMagnitude(input, positive, magnitudePositive)
-	if !ok {
-		return "", false
+// appendDecodedBase validates the token and appends the number it holds to the buffer.
+// The buffer is unchanged on failure.
+func (c *Codec) appendDecodedBase(input string, base int) error {
+	if input == "" {
+		return nil
 	}
 
-	encodedLength := len(input)
-	if !positive {
-		if input[encodedLength-1] != negativeNumberTerminator {
-			return "", false
-		}
+	if input == zeroOutput {
+		c.buf = append(c.buf, zeroInput...)
+		c.formatNumber(len(c.buf) - len(zeroInput))
+		return nil
+	}
 
-		encodedLength--
+	t, err := c.parseTokenBase(input, base)
+	if err != nil {
+		return err
 	}
 
+	positive, magnitudePositive, magnitude := t.positive, t.magnitudePositive, t.magnitude
+	sStartPos, encodedLength := t.sStartPos, t.sEndPos
 	significantPartLength := encodedLength - sStartPos
 
-	for i := sStartPos; i < encodedLength; i++ {
-		if !isDigit(input[i]) {
-			return "", false
-		}
-	}
-
-	c.builder.Reset()
-	c.builder.Grow(c.calculateDecodedLength(positive, magnitudePositive, magnitude, significantPartLength))
+	c.grow(c.calculateDecodedLength(positive, magnitudePositive, magnitude, significantPartLength))
+	start := len(c.buf)
 
 	if !positive {
-		c.builder.WriteByte(minusByte)
+		c.buf = append(c.buf, minusByte)
 	}
 	if !magnitudePositive {
-		c.builder.WriteByte(digit0)
-		c.builder.WriteByte(decimalPoint)
+		c.buf = append(c.buf, digit0, decimalPoint)
 		for i := 0; i < magnitude; i++ {
-			c.builder.WriteByte(digit0)
+			c.buf = append(c.buf, digit0)
 		}
 		c.writeDigits(positive, input[sStartPos:encodedLength])
 	} else {
 		if magnitude >= significantPartLength {
 			c.writeDigits(positive, input[sStartPos:encodedLength])
 			for i := 0; i < magnitude-significantPartLength; i++ {
-				c.builder.WriteByte(digit0)
+				c.buf = append(c.buf, digit0)
 			}
 		} else {
 			c.writeDigits(positive, input[sStartPos:sStartPos+magnitude])
-			c.builder.WriteByte(decimalPoint)
+			c.buf = append(c.buf, decimalPoint)
 			c.writeDigits(positive, input[sStartPos+magnitude:encodedLength])
 		}
 	}
 
-	return c.builder.String(), true
+	c.formatNumber(start)
+	return nil
+}
+
+// DecodeTokenUpper works like DecodeToken, but the letter digits of the output are uppercase.
+func (c *Codec) DecodeTokenUpper(input string) (out string, ok bool) {
+	out, ok = c.DecodeToken(input)
+	if !ok {
+		return "", false
+	}
+	return strings.ToUpper(out), true
 }
 
 // EncodeMixedText is a convinience function that replaces all groups of decimal numbers of the input
 // with Conust strings also surrounding them with spaces (if not already present) to ensure the expected ordering
 func (c *Codec) EncodeMixedText(input string) (out string, ok bool) {
+	out, err := c.EncodeMixedTextErr(input)
+	return out, err == nil
+}
+
+// EncodeMixedTextErr works like EncodeMixedText, but it returns a *SyntaxError describing the first number
+// that could not be encoded, with the offset counted from the start of the whole input.
+func (c *Codec) EncodeMixedTextErr(input string) (out string, err error) {
+	c.buf = c.emptyBuf()
+	err = c.appendMixedText(input)
+	return string(c.buf), err
+}
+
+// AppendMixedText works like EncodeMixedTextErr, but it appends the output to dst and returns the extended buffer.
+// Unlike the other append functions, the output is appended even if some of the numbers could not be encoded.
+func (c *Codec) AppendMixedText(dst []byte, src []byte) ([]byte, error) {
+	// dst must not be dropped as an inherited buffer
+	c.ownBuffers()
+	buf := c.buf
+	c.buf = dst
+	err := c.appendMixedText(bytesToString(src))
+	dst, c.buf = c.buf, buf
+	return dst, err
+}
+
+func (c *Codec) appendMixedText(input string) (err error) {
+	separator := c.separatorByte()
 	insideNumber := false
 	donePartEnd := 0
-	var b strings.Builder
-	ok = true
-	b.Grow(len(input) + 6)
+	c.grow(len(input) + 6)
 
 	for i := 0; i < len(input); i++ {
 		if input[i] >= digit0 && input[i] <= digit9 {
 			if !insideNumber {
-				b.Write([]byte(input[donePartEnd:i]))
+				c.buf = append(c.buf, input[donePartEnd:i]...)
 				donePartEnd = i
 				insideNumber = true
-				if i > 0 && input[i-1] != inTextSeparator {
-					b.WriteByte(inTextSeparator)
+				if i > 0 && input[i-1] != separator {
+					c.buf = append(c.buf, separator)
 				}
 			}
 			continue
 		}
 		if insideNumber {
-			encoded, encOk := c.EncodeToken(input[donePartEnd:i])
-			if encOk {
-				b.WriteString(encoded)
-			} else {
-				b.WriteString(input[donePartEnd:i])
-				ok = false
+			if encErr := c.appendStandardTokenBase(input[donePartEnd:i], maxBase); encErr != nil {
+				c.buf = append(c.buf, input[donePartEnd:i]...)
+				if err == nil {
+					err = encErr.(*SyntaxError).shift(donePartEnd)
+				}
 			}
 			insideNumber = false
 			donePartEnd = i
-			if input[i] != inTextSeparator {
-				b.WriteByte(inTextSeparator)
+			if input[i] != separator {
+				c.buf = append(c.buf, separator)
 			}
 		}
 	}
 	if !insideNumber {
-		b.WriteString(input[donePartEnd:])
+		c.buf = append(c.buf, input[donePartEnd:]...)
 	} else {
-		encoded, encOk := c.EncodeToken(input[donePartEnd:])
-		if encOk {
-			b.WriteString(encoded)
-		} else {
-			b.WriteString(input[donePartEnd:])
-			ok = false
+		if encErr := c.appendStandardTokenBase(input[donePartEnd:], maxBase); encErr != nil {
+			c.buf = append(c.buf, input[donePartEnd:]...)
+			if err == nil {
+				err = encErr.(*SyntaxError).shift(donePartEnd)
+			}
 		}
 	}
 
-	out = b.String()
-	return
+	return err
 }
 
-func (c *Codec) isValidInput(input string) bool {
+func (c *Codec) validateInput(input string, base int) error {
 	if !isSignByte(input[0]) && !isDigit(input[0]) {
-		return false
+		return newSyntaxError(input, 0, ReasonInvalidCharacter)
+	}
+	if isDigit(input[0]) && digitToInt(input[0]) >= base {
+		return newSyntaxError(input, 0, ReasonDigitOutOfBase)
 	}
 
 	decimalPointAlreadyFound := false
 	for i := 1; i < len(input); i++ {
 		if isDigit(input[i]) {
+			if digitToInt(input[i]) >= base {
+				return newSyntaxError(input, i, ReasonDigitOutOfBase)
+			}
 			continue
 		}
 
-		if decimalPointAlreadyFound {
-			return false
-		}
-
 		if input[i] == decimalPoint {
+			if decimalPointAlreadyFound {
+				return newSyntaxError(input, i, ReasonMultipleDecimalPoints)
+			}
 			decimalPointAlreadyFound = true
 			continue
 		}
 
-		return false
+		return newSyntaxError(input, i, ReasonInvalidCharacter)
+	}
+
+	return nil
+}
+
+func (c *Codec) isValidDecimalInput(input string) bool {
+	for i := 0; i < len(input); i++ {
+		if input[i] >= digitA && input[i] <= digitZ {
+			return false
+		}
+	}
+	return c.validateInput(input, maxBase) == nil
+}
+
+func (c *Codec) parseExponent(input string) (exponent int, ok bool) {
+	i := 0
+	if len(input) > 0 && isSignByte(input[0]) {
+		i++
+	}
+	if i == len(input) {
+		return 0, false
+	}
+
+	for ; i < len(input); i++ {
+		if input[i] < digit0 || input[i] > digit9 {
+			return 0, false
+		}
+		// checked before the multiplication, so that it can not overflow
+		digitValue := int(input[i] - digit0)
+		if exponent > (maxExponent-digitValue)/10 {
+			return 0, false
+		}
+		exponent = exponent*10 + digitValue
 	}
 
-	return true
+	if input[0] == minusByte {
+		exponent = -exponent
+	}
+	return exponent, true
 }
 
 func (c *Codec) getPositivity(input string) (positive bool) {
@@ -235,7 +506,7 @@ func (c *Codec) getDecimalPointPos(input string) int {
 	return strings.IndexByte(input, decimalPoint)
 }
 
-func (c *Codec) getMagnitudeParams(inputLength int, sStartPos int, sEndPos int, decimalPointPos int) (magnitude int, magnitudePositive bool) {
+func (c *Codec) getMagnitudeParams(inputLength int, sStartPos int, sEndPos int, decimalPointPos int, exponent int) (magnitude int, magnitudePositive bool) {
 	if decimalPointPos < 0 {
 		magnitude = inputLength - sStartPos
 		magnitudePositive = true
@@ -246,11 +517,25 @@ func (c *Codec) getMagnitudeParams(inputLength int, sStartPos int, sEndPos int,
 		magnitude = decimalPointPos - sStartPos
 		magnitudePositive = true
 	}
+
+	if exponent != 0 {
+		// the number is 0.ddd * 10^integerDigits, where integerDigits is not positive for numbers below 1
+		integerDigits := magnitude
+		if !magnitudePositive {
+			integerDigits = -magnitude
+		}
+		integerDigits += exponent
+		if integerDigits > 0 {
+			return integerDigits, true
+		}
+		return -integerDigits, false
+	}
 	return
 }
 
 func (c *Codec) calculateEncodedSize(positive bool, magnitude int, sStartPos int, sEndPos int, decimalPointPos int) int {
-	length := 2 + (magnitude / maxMagnitudeDigitValue) + sEndPos - sStartPos
+	// the magnitude takes one digit for every started maxMagnitudeDigitValue, but at least one
+	length := 2 + (magnitude-1)/maxMagnitudeDigitValue + sEndPos - sStartPos
 	if !positive {
 		length++
 	}
@@ -273,32 +558,163 @@ func (c *Codec) encodeSign(positive bool, magnitudePositive bool) byte {
 	return signNegativeMagNegative
 }
 
+// ownBuffers drops the buffers inherited from another Codec when c is a copy of it,
+// so that the copy never writes into the buffers of the original.
+func (c *Codec) ownBuffers() {
+	if c.self != c {
+		c.self = c
+		c.buf = nil
+		c.scratch = nil
+	}
+}
+
+// emptyBuf returns the buffer emptied, for the start of building a new output.
+func (c *Codec) emptyBuf() []byte {
+	c.ownBuffers()
+	return c.buf[:0]
+}
+
+// emptyScratch returns the scratch buffer emptied, for the start of building a new intermediate value.
+func (c *Codec) emptyScratch() []byte {
+	c.ownBuffers()
+	return c.scratch[:0]
+}
+
+// grow makes sure that n more bytes can be appended to the buffer without allocation.
+func (c *Codec) grow(n int) {
+	if cap(c.buf)-len(c.buf) < n {
+		grown := make([]byte, len(c.buf), 2*cap(c.buf)+n)
+		copy(grown, c.buf)
+		c.buf = grown
+	}
+}
+
 func (c *Codec) writeMagnitude(positive bool, magnitudePositive bool, magnitude int) {
 	reverseDigits := positive != magnitudePositive
 	for ; magnitude > maxMagnitudeDigitValue; magnitude -= maxMagnitudeDigitValue {
 		if reverseDigits {
-			c.builder.WriteByte(intToReversedDigit(maxDigitValue))
+			c.buf = append(c.buf, intToReversedDigit(maxDigitValue))
 		} else {
-			c.builder.WriteByte(intToDigit(maxDigitValue))
+			c.buf = append(c.buf, intToDigit(maxDigitValue))
 		}
 	}
 	if reverseDigits {
-		c.builder.WriteByte(intToReversedDigit(magnitude))
+		c.buf = append(c.buf, intToReversedDigit(magnitude))
 	} else {
-		c.builder.WriteByte(intToDigit(magnitude))
+		c.buf = append(c.buf, intToDigit(magnitude))
 	}
 }
 
 func (c *Codec) writeDigits(positive bool, digits string) {
 	if positive {
-		c.builder.WriteString(digits)
+		c.buf = append(c.buf, digits...)
 	} else {
 		for i := 0; i < len(digits); i++ {
-			c.builder.WriteByte(reverseDigit(digits[i]))
+			c.buf = append(c.buf, reverseDigit(digits[i]))
 		}
 	}
 }
 
+// writeToken assembles a non-zero token from its parts. The digits must be the significant digits of the number
+// without leading and trailing zeros.
+func (c *Codec) writeToken(positive bool, magnitudePositive bool, magnitude int, digits []byte) string {
+	c.buf = c.emptyBuf()
+	c.appendTokenParts(positive, magnitudePositive, magnitude, digits)
+	return string(c.buf)
+}
+
+func (c *Codec) appendTokenParts(positive bool, magnitudePositive bool, magnitude int, digits []byte) {
+	c.grow(c.calculateEncodedSize(positive, magnitude, 0, len(digits), -1))
+	c.buf = append(c.buf, c.encodeSign(positive, magnitudePositive))
+	c.writeMagnitude(positive, magnitudePositive, magnitude)
+	c.writeDigitBytes(positive, digits)
+	if !positive {
+		c.buf = append(c.buf, c.terminatorByte())
+	}
+}
+
+func (c *Codec) writeDigitBytes(positive bool, digits []byte) {
+	if positive {
+		c.buf = append(c.buf, digits...)
+	} else {
+		for i := 0; i < len(digits); i++ {
+			c.buf = append(c.buf, reverseDigit(digits[i]))
+		}
+	}
+}
+
+// tokenLayout describes the parts of a non-zero token. The significant digits occupy
+// input[sStartPos:sEndPos], which excludes the terminator of negative numbers.
+type tokenLayout struct {
+	positive          bool
+	magnitudePositive bool
+	magnitude         int
+	sStartPos         int
+	sEndPos           int
+}
+
+// parseToken parses a token holding digits of any base, for the decoders that do not write the digits
+// with the alphabet of the codec.
+func (c *Codec) parseToken(input string) (t tokenLayout, err error) {
+	return c.parseTokenDigits(input, maxBase)
+}
+
+// parseTokenBase parses a token holding a number of the given base, which is limited to the length
+// of the alphabet of the codec, since the digits above it can not be written.
+func (c *Codec) parseTokenBase(input string, base int) (t tokenLayout, err error) {
+	if highest := c.highestBase(); base > highest {
+		base = highest
+	}
+	return c.parseTokenDigits(input, base)
+}
+
+// parseTokenDigits parses a token holding digits of the given base regardless of the alphabet of the codec,
+// for the tokens whose digits are not written with the alphabet.
+func (c *Codec) parseTokenDigits(input string, base int) (t tokenLayout, err error) {
+	if len(input) < 3 {
+		return t, newSyntaxError(input, len(input), ReasonUnexpectedEnd)
+	}
+
+	var ok bool
+	t.positive, t.magnitudePositive, ok = c.decodeSigns(input)
+	if !ok {
+		return t, newSyntaxError(input, 0, ReasonInvalidSign)
+	}
+
+	var next int
+	t.magnitude, next, ok = c.decodeMagnitude(input, t.positive, t.magnitudePositive)
+	if !ok {
+		if next < len(input) {
+			return t, newSyntaxError(input, next, ReasonInvalidMagnitude)
+		}
+		return t, newSyntaxError(input, next, ReasonUnexpectedEnd)
+	}
+	t.sStartPos = next
+
+	t.sEndPos = len(input)
+	if !t.positive {
+		if input[t.sEndPos-1] != c.terminatorByte() {
+			return t, newSyntaxError(input, t.sEndPos-1, ReasonMissingTerminator)
+		}
+		t.sEndPos--
+	}
+
+	if t.sStartPos >= t.sEndPos {
+		return t, newSyntaxError(input, t.sEndPos, ReasonUnexpectedEnd)
+	}
+
+	for i := t.sStartPos; i < t.sEndPos; i++ {
+		if !isDigit(input[i]) {
+			return t, newSyntaxError(input, i, ReasonInvalidCharacter)
+		}
+		if (t.positive && digitToInt(input[i]) >= base) || (!t.positive && reversedDigitToInt(input[i]) >= base) {
+			return t, newSyntaxError(input, i, ReasonDigitOutOfBase)
+		}
+	}
+
+	return t, nil
+}
+
 func (c *Codec) decodeSigns(in string) (positive bool, magnitudePositive bool, ok bool) {
 	switch in[0] {
 	case signPositiveMagPositive:
@@ -315,9 +731,17 @@ func (c *Codec) decodeSigns(in string) (positive bool, magnitudePositive bool, o
 }
 
 func (c *Codec) decodeMagnitude(in string, positive bool, magnitudePositive bool) (magnitude int, significantPartPos int, ok bool) {
-	reverseDigits := positive != magnitudePositive
+	return c.decodeMagnitudeAt(in, 1, positive != magnitudePositive)
+}
+
+// decodeMagnitudeAt reads the magnitude starting at pos. On failure next is the position of the problem.
+func (c *Codec) decodeMagnitudeAt(in string, pos int, reverseDigits bool) (magnitude int, next int, ok bool) {
 	var digitValue int
-	for i := 1; i < len(in); i++ {
+	for i := pos; i < len(in); i++ {
+		if !isDigit(in[i]) {
+			return 0, i, false
+		}
+
 		if reverseDigits {
 			digitValue = reversedDigitToInt(in[i])
 		} else {
@@ -328,12 +752,12 @@ func (c *Codec) decodeMagnitude(in string, positive bool, magnitudePositive bool
 			magnitude += maxMagnitudeDigitValue
 		} else {
 			magnitude += digitValue
-			significantPartPos = i + 1
+			next = i + 1
 			ok = true
 			return
 		}
 	}
-	return 0, 0, false
+	return 0, len(in), false
 }
 
 func (c *Codec) calculateDecodedLength(positive bool, magnitudePositive bool, magnitude int, significantPartLength int) int {
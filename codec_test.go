@@ -1,9 +1,11 @@
 package conust
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -189,6 +191,387 @@ func TestCodec_DecodeToken_Failure(t *testing.T) {
 	}
 }
 
+func TestCodec_AnyCase(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		input   string
+		encoded string
+		decoded string
+	}{
+		{name: "uppercase hex", input: "FF.8", encoded: "72ff8", decoded: "FF.8"},
+		{name: "mixed case hex", input: "-fF.8", encoded: "3xkkr~", decoded: "-FF.8"},
+		{name: "lowercase", input: "cowboy.hat", encoded: "76cowboyhat", decoded: "COWBOY.HAT"},
+		{name: "no letters", input: "1200", encoded: "7412", decoded: "1200"},
+		{name: "zero", input: "0.0", encoded: "5", decoded: "0"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded, ok := codec.EncodeTokenAnyCase(i.input)
+
+			if !ok {
+				t.Fatalf("Encoding failed for: %v\n", i.input)
+			}
+
+			if i.encoded != encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			decoded, ok := codec.DecodeTokenUpper(encoded)
+
+			if !ok {
+				t.Fatalf("Decoding failed for: %v <- %v\n", i.input, decoded)
+			}
+
+			if i.decoded != decoded {
+				t.Fatalf("Decoding expected: %v, got %v\n", i.decoded, decoded)
+			}
+		})
+	}
+
+	for _, input := range []string{"F F", "1.2.F", "\u212a"} {
+		if encoded, ok := codec.EncodeTokenAnyCase(input); ok || encoded != "" {
+			t.Fatalf("Encoding should have failed for: %v\n", input)
+		}
+	}
+	if decoded, ok := codec.DecodeTokenUpper("72FF8"); ok || decoded != "" {
+		t.Fatal("Decoding should have failed for an uppercase token")
+	}
+}
+
+func TestCodec_Append(t *testing.T) {
+	inputs := []string{
+		"", "0", "-000", "1", "-1", "+00000123000", "-00000123000", "54321.12345", "-54321.12345",
+		"0.0012", "-0.0012", "1234567890abcdefghij.klmnopqrstuvwxyz", "-cowboy.hat",
+		"12345678901234567890123456789012345.1", "-12345678901234567890123456789012345.1",
+		"1234567890123456789012345678901234", "12345678901234567890123456789012345",
+		"0.000000000000000000000000000000000004325430", "-12000000000000000000000000000000000000",
+	}
+
+	codec := new(Codec)
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			expected, _ := codec.EncodeToken(input)
+			prefix := []byte("prefix:")
+
+			encodedLen, err := codec.EncodedLen([]byte(input))
+			if err != nil || encodedLen != len(expected) {
+				t.Fatalf("EncodedLen expected: %v, got %v, %v\n", len(expected), encodedLen, err)
+			}
+
+			encoded, err := codec.AppendToken(prefix, []byte(input))
+			if err != nil || string(encoded) != "prefix:"+expected {
+				t.Fatalf("AppendToken expected: %v, got %s, %v\n", expected, encoded, err)
+			}
+
+			expectedDecoded, _ := codec.DecodeToken(expected)
+
+			decodedLen, err := codec.DecodedLen([]byte(expected))
+			if err != nil || decodedLen != len(expectedDecoded) {
+				t.Fatalf("DecodedLen expected: %v, got %v, %v\n", len(expectedDecoded), decodedLen, err)
+			}
+
+			decoded, err := codec.AppendDecoded(prefix, []byte(expected))
+			if err != nil || string(decoded) != "prefix:"+expectedDecoded {
+				t.Fatalf("AppendDecoded expected: %v, got %s, %v\n", expectedDecoded, decoded, err)
+			}
+		})
+	}
+
+	mixed, err := codec.AppendMixedText([]byte("Item "), []byte("SomeCam1100D"))
+	if err != nil || string(mixed) != "Item SomeCam 7411 D" {
+		t.Fatalf("AppendMixedText expected: %v, got %s, %v\n", "Item SomeCam 7411 D", mixed, err)
+	}
+}
+
+func TestCodec_Append_Failure(t *testing.T) {
+	codec := new(Codec)
+	dst := []byte("prefix:")
+
+	out, err := codec.AppendToken(dst, []byte("1.2.3"))
+	if !errors.Is(err, ErrSyntax) || string(out) != "prefix:" {
+		t.Fatalf("AppendToken should have failed, got %s, %v\n", out, err)
+	}
+	out, err = codec.AppendDecoded(dst, []byte("40zx"))
+	if !errors.Is(err, ErrSyntax) || string(out) != "prefix:" {
+		t.Fatalf("AppendDecoded should have failed, got %s, %v\n", out, err)
+	}
+	if _, err := codec.EncodedLen([]byte("X")); !errors.Is(err, ErrSyntax) {
+		t.Fatalf("EncodedLen should have failed, got %v\n", err)
+	}
+	if _, err := codec.DecodedLen([]byte("2z412")); !errors.Is(err, ErrSyntax) {
+		t.Fatalf("DecodedLen should have failed, got %v\n", err)
+	}
+}
+
+func TestCodec_Append_Allocations(t *testing.T) {
+	codec := new(Codec)
+	input := []byte("-54321.12345")
+	token := []byte("3uuvwxyyxwvu~")
+	text := []byte("SomeCam1100D")
+	dst := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		dst, _ = codec.AppendToken(dst[:0], input)
+		dst, _ = codec.AppendDecoded(dst[:0], token)
+		dst, _ = codec.AppendMixedText(dst[:0], text)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestCodec_EncodeTokenErr(t *testing.T) {
+	codecTests := []struct {
+		name   string
+		input  string
+		offset int
+		char   byte
+		reason SyntaxErrorReason
+	}{
+		{name: "leading space", input: " 123", offset: 0, char: ' ', reason: ReasonInvalidCharacter},
+		{name: "trailing space", input: "123 ", offset: 3, char: ' ', reason: ReasonInvalidCharacter},
+		{name: "uppercase", input: "12X3", offset: 2, char: 'X', reason: ReasonInvalidCharacter},
+		{name: "second decimal point", input: "1.2.3", offset: 3, char: '.', reason: ReasonMultipleDecimalPoints},
+		{name: "invalid after decimal point", input: "1.2X", offset: 3, char: 'X', reason: ReasonInvalidCharacter},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded, err := codec.EncodeTokenErr(i.input)
+
+			if encoded != "" || !errors.Is(err, ErrSyntax) {
+				t.Fatalf("Encoding should have failed for: %v\n", i.input)
+			}
+
+			syntaxErr, ok := err.(*SyntaxError)
+			if !ok {
+				t.Fatalf("Expected a *SyntaxError, got %T\n", err)
+			}
+
+			expected := SyntaxError{Offset: i.offset, Byte: i.char, Reason: i.reason}
+			if *syntaxErr != expected {
+				t.Fatalf("Error expected: %v, got %v\n", expected, *syntaxErr)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeTokenErr(t *testing.T) {
+	codecTests := []struct {
+		name   string
+		input  string
+		offset int
+		char   byte
+		reason SyntaxErrorReason
+	}{
+		{name: "too short", input: "4b", offset: 2, char: 0, reason: ReasonUnexpectedEnd},
+		{name: "bad prefix", input: "2z412", offset: 0, char: '2', reason: ReasonInvalidSign},
+		{name: "magnitude error", input: "600", offset: 3, char: 0, reason: ReasonUnexpectedEnd},
+		{name: "non digit magnitude", input: "7!12", offset: 1, char: '!', reason: ReasonInvalidMagnitude},
+		{name: "no significant digits", input: "3y~", offset: 2, char: '~', reason: ReasonUnexpectedEnd},
+		{name: "no negative terminator", input: "40zx", offset: 3, char: 'x', reason: ReasonMissingTerminator},
+		{name: "non digit char", input: "7z412X", offset: 5, char: 'X', reason: ReasonInvalidCharacter},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeTokenErr(i.input)
+
+			if decoded != "" || !errors.Is(err, ErrSyntax) {
+				t.Fatalf("Decoding should have failed for: %v\n", i.input)
+			}
+
+			syntaxErr, ok := err.(*SyntaxError)
+			if !ok {
+				t.Fatalf("Expected a *SyntaxError, got %T\n", err)
+			}
+
+			expected := SyntaxError{Offset: i.offset, Byte: i.char, Reason: i.reason}
+			if *syntaxErr != expected {
+				t.Fatalf("Error expected: %v, got %v\n", expected, *syntaxErr)
+			}
+		})
+	}
+}
+
+func TestCodec_TokenBase(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		input   string
+		base    int
+		encoded string
+		decoded string
+	}{
+		{name: "binary", input: "-101.1", base: 2, encoded: "3wyzyy~", decoded: "-101.1"},
+		{name: "octal", input: "1777", base: 8, encoded: "741777", decoded: "1777"},
+		{name: "decimal", input: "0.0012", base: 10, encoded: "6x12", decoded: "0.0012"},
+		{name: "hex", input: "ff.8", base: 16, encoded: "72ff8", decoded: "ff.8"},
+		{name: "base 36", input: "cowboy.hat", base: 36, encoded: "76cowboyhat", decoded: "cowboy.hat"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded, err := codec.EncodeTokenBase(i.input, i.base)
+
+			if err != nil {
+				t.Fatalf("Encoding failed for: %v: %v\n", i.input, err)
+			}
+
+			if i.encoded != encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			decoded, err := codec.DecodeTokenBase(encoded, i.base)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if i.decoded != decoded {
+				t.Fatalf("Decoding expected: %v, got %v\n", i.decoded, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_TokenBase_Failure(t *testing.T) {
+	codecTests := []struct {
+		name   string
+		input  string
+		base   int
+		decode bool
+		offset int
+		char   byte
+	}{
+		{name: "octal typo", input: "19", base: 8, offset: 1, char: '9'},
+		{name: "first digit", input: "9X", base: 8, offset: 0, char: '9'},
+		{name: "fraction", input: "-1.2", base: 2, offset: 3, char: '2'},
+		{name: "letter in decimal", input: "12a", base: 10, offset: 2, char: 'a'},
+		{name: "decode octal typo", input: "7219", base: 8, decode: true, offset: 3, char: '9'},
+		{name: "decode negative", input: "3xyq~", base: 8, decode: true, offset: 3, char: 'q'},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			var out string
+			var err error
+			if i.decode {
+				out, err = codec.DecodeTokenBase(i.input, i.base)
+			} else {
+				out, err = codec.EncodeTokenBase(i.input, i.base)
+			}
+
+			syntaxErr, ok := err.(*SyntaxError)
+			if out != "" || !ok {
+				t.Fatalf("Expected a *SyntaxError for: %v, got %v, %v\n", i.input, out, err)
+			}
+
+			expected := SyntaxError{Offset: i.offset, Byte: i.char, Reason: ReasonDigitOutOfBase}
+			if *syntaxErr != expected {
+				t.Fatalf("Error expected: %v, got %v\n", expected, *syntaxErr)
+			}
+		})
+	}
+
+	if _, err := codec.EncodeTokenBase("1", 1); err != ErrBase {
+		t.Fatalf("Expected %v, got %v\n", ErrBase, err)
+	}
+	if _, err := codec.DecodeTokenBase("711", 37); err != ErrBase {
+		t.Fatalf("Expected %v, got %v\n", ErrBase, err)
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	err := &SyntaxError{Offset: 3, Byte: '.', Reason: ReasonMultipleDecimalPoints}
+	if err.Error() != `conust: multiple decimal points '.' at offset 3` {
+		t.Fatal("unexpected error message:", err.Error())
+	}
+
+	err = &SyntaxError{Offset: 2, Reason: ReasonUnexpectedEnd}
+	if err.Error() != `conust: unexpected end of input at offset 2` {
+		t.Fatal("unexpected error message:", err.Error())
+	}
+}
+
+func TestCodec_EncodeScientificToken(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		input   string
+		encoded string
+	}{
+		{name: "empty", input: "", encoded: ""},
+		{name: "no exponent", input: "1200", encoded: "7412"},
+		{name: "no exponent fractional", input: "-0.0012", encoded: "42yx~"},
+		{name: "zero", input: "0e10", encoded: "5"},
+		{name: "negative zero", input: "-0.000E-10", encoded: "5"},
+		{name: "zero exponent", input: "1.2e0", encoded: "7112"},
+		{name: "positive exponent", input: "1.2e3", encoded: "7412"},
+		{name: "signed positive exponent", input: "1.2E+3", encoded: "7412"},
+		{name: "negative exponent", input: "1.2e-3", encoded: "6x12"},
+		{name: "exponent crossing one", input: "0.0012e3", encoded: "7112"},
+		{name: "exponent to zero integer digits", input: "12e-2", encoded: "6z12"},
+		{name: "negative number", input: "-12e-5", encoded: "43yx~"},
+		{name: "leading and trailing zeros", input: "+00120.0e-1", encoded: "7212"},
+		{name: "example 1", input: "1.2e37", encoded: "7z412"},
+		{name: "example 6.2", input: "1.2e-36", encoded: "60y12"},
+		{name: "example 12", input: "-1.2e37", encoded: "30vyx~"},
+		{name: "large exponent", input: "1.5e300", encoded: "7zzzzzzzzt15"},
+		{name: "small exponent", input: "1.5e-300", encoded: "600000000815"},
+		{name: "largest exponent", input: "1e10000", encoded: "7" + strings.Repeat("z", 294) + "51"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded, ok := codec.EncodeScientificToken(i.input)
+
+			if !ok {
+				t.Fatalf("Encoding failed for: %v\n", i.input)
+			}
+
+			if i.encoded != encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+		})
+	}
+}
+
+func TestCodec_EncodeScientificToken_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+	}{
+		{name: "letter digit", input: "1a"},
+		{name: "no mantissa", input: "e5"},
+		{name: "no exponent", input: "1e"},
+		{name: "sign only exponent", input: "1e-"},
+		{name: "fractional exponent", input: "1e1.5"},
+		{name: "multiple exponents", input: "1e1e1"},
+		{name: "multiple decimal points", input: "1.2.3e1"},
+		{name: "exponent too large", input: "1e99999999999"},
+		{name: "exponent above the cap", input: "1e10001"},
+		{name: "negative exponent above the cap", input: "1e-10001"},
+		{name: "exponent wrapping around 32 bits", input: "1e4294967297"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded, ok := codec.EncodeScientificToken(i.input)
+
+			if ok || encoded != "" {
+				t.Fatalf("Encoding should have failed for: %v\n", i.input)
+			}
+		})
+	}
+}
+
 func TestSortedness(t *testing.T) {
 	step := 0.01
 	prev := LessThanAny
@@ -3,6 +3,7 @@
 // The input is not limited to decimal numbers, other bases up to base 36 are accepted with the
 // restriction that it must be lowercased.
 // The expected input format is ^[+-]?[0-9a-z]+(\.[0-9a-z]+)?$ Failing to satisfy this results in encoding failures.
+// Decimal numbers can also be encoded from scientific notation, like 1.5e-300.
 //
 // Transforming tokens back into numbers is also possible. This operation requires that the
 // tokens are as they were generated by the encoder, modifications to them might cause decoding failures.
@@ -16,6 +17,8 @@
 // is not possible.
 package conust
 
+import "unsafe"
+
 // [48 49 50 51 52 53 54 55 56 57
 // 97 98 99 100 101 102 103 104 105 106
 // 107 108 109 110 111 112 113 114 115 116
@@ -32,6 +35,9 @@ var digits36Reversed = [...]byte{
 	'f', 'e', 'd', 'c', 'b', 'a', '9', '8', '7', '6',
 	'5', '4', '3', '2', '1', '0'}
 
+const minBase = 2
+const maxBase = 36
+
 const maxDigitValue = 35
 const maxMagnitudeDigitValue = 34
 
@@ -40,6 +46,8 @@ const digit1 byte = '1'
 const digit9 byte = '9'
 const digitA byte = 'a'
 const digitZ byte = 'z'
+const digitUpperA byte = 'A'
+const digitUpperZ byte = 'Z'
 const minusByte byte = '-'
 const plusByte byte = '+'
 
@@ -59,6 +67,11 @@ const GreaterThanAny = "8"
 
 const zeroInput = "0"
 
+// maxExponent is the largest absolute value of the exponent accepted in scientific notation. It is well above
+// the exponent range of the common float formats (the widest, binary128, goes to 4932), while it keeps a short
+// untrusted input from creating a huge token, since the magnitude takes one byte for every 34 of its value.
+const maxExponent = 10000
+
 const decimalPoint byte = '.'
 const negativeNumberTerminator byte = '~'
 const inTextSeparator byte = ' '
@@ -72,6 +85,10 @@ func isDigit(digit byte) bool {
 		(digit >= digitA && digit <= digitZ)
 }
 
+func isUpperDigit(digit byte) bool {
+	return digit >= digitUpperA && digit <= digitUpperZ
+}
+
 func digitToInt(digit byte) int {
 	if digit < digitA {
 		return int(digit - digit0)
@@ -97,3 +114,9 @@ func intToReversedDigit(i int) byte {
 func reverseDigit(digit byte) byte {
 	return intToReversedDigit(digitToInt(digit))
 }
+
+// bytesToString returns a string sharing its memory with b, so that byte slice inputs can be processed
+// without copying. The string must not be retained, since the content of b might change later.
+func bytesToString(b []byte) string {
+	return *(*string)(unsafe.Pointer(&b))
+}
@@ -0,0 +1,69 @@
+package conust
+
+// EncodeTokenDesc works like EncodeTokenErr, but the order of the tokens is the reverse of the order of the numbers,
+// so that an ascending scan of the keys returns the largest numbers first.
+// The token of a number is the ascending token of its negation: the sign byte range, the magnitude and the digits
+// are all inverted, and the terminator moves to the tokens of positive numbers, where the prefix problem arises.
+// The tokens still fall between LessThanAny and GreaterThanAny.
+func (c *Codec) EncodeTokenDesc(input string) (out string, err error) {
+	c.buf = c.emptyBuf()
+	if err := c.appendTokenBase(input, maxBase); err != nil {
+		return "", err
+	}
+	if len(c.buf) == 0 {
+		return "", nil
+	}
+
+	c.scratch = c.appendNegatedToken(c.emptyScratch(), bytesToString(c.buf))
+	return string(c.scratch), nil
+}
+
+// DecodeTokenDesc turns a token created by EncodeTokenDesc back into its normal representation.
+// On failure it returns a *SyntaxError describing the problem.
+func (c *Codec) DecodeTokenDesc(input string) (out string, err error) {
+	if input == "" {
+		return "", nil
+	}
+	if input != zeroOutput {
+		if _, err := c.parseToken(input); err != nil {
+			return "", err
+		}
+	}
+
+	c.scratch = c.appendNegatedToken(c.emptyScratch(), input)
+	return c.decodeTokenBase(bytesToString(c.scratch), maxBase)
+}
+
+// appendNegatedToken appends the token of the negated value of a valid token to dst.
+func (c *Codec) appendNegatedToken(dst []byte, token string) []byte {
+	if token == zeroOutput {
+		return append(dst, token...)
+	}
+
+	positive := token[0] == signPositiveMagPositive || token[0] == signPositiveMagNegative
+	if !positive {
+		token = token[:len(token)-1]
+	}
+
+	dst = append(dst, negateSign(token[0]))
+	for i := 1; i < len(token); i++ {
+		dst = append(dst, reverseDigit(token[i]))
+	}
+	if positive {
+		dst = append(dst, c.terminatorByte())
+	}
+	return dst
+}
+
+func negateSign(sign byte) byte {
+	switch sign {
+	case signPositiveMagPositive:
+		return signNegativeMagPositive
+	case signPositiveMagNegative:
+		return signNegativeMagNegative
+	case signNegativeMagNegative:
+		return signPositiveMagNegative
+	default:
+		return signPositiveMagPositive
+	}
+}
@@ -0,0 +1,87 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodec_TokenDesc(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		encoded string
+		decoded string
+	}{
+		{name: "empty", input: "", encoded: "", decoded: ""},
+		{name: "zero", input: "-0.00", encoded: "5", decoded: "0"},
+		{name: "one", input: "1", encoded: "3yy~", decoded: "1"},
+		{name: "negative one", input: "-1", encoded: "711", decoded: "-1"},
+		{name: "fraction", input: "0.000125", encoded: "43yxu~", decoded: "0.000125"},
+		{name: "negative fraction", input: "-3.14", encoded: "71314", decoded: "-3.14"},
+		{name: "letters", input: "cowboy.hat", encoded: "3tnb3ob1ip6~", decoded: "cowboy.hat"},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := c.EncodeTokenDesc(tc.input)
+			if err != nil || encoded != tc.encoded {
+				t.Fatalf("EncodeTokenDesc(%q) = %q, %v, expected %q", tc.input, encoded, err, tc.encoded)
+			}
+			decoded, err := c.DecodeTokenDesc(encoded)
+			if err != nil || decoded != tc.decoded {
+				t.Fatalf("DecodeTokenDesc(%q) = %q, %v, expected %q", encoded, decoded, err, tc.decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_TokenDesc_Failure(t *testing.T) {
+	c := new(Codec)
+
+	var syntaxErr *SyntaxError
+	if _, err := c.EncodeTokenDesc("1.2.3"); !errors.As(err, &syntaxErr) || syntaxErr.Offset != 3 {
+		t.Errorf("EncodeTokenDesc error = %v, expected a *SyntaxError at offset 3", err)
+	}
+	if _, err := c.DecodeTokenDesc("3yy"); !errors.As(err, &syntaxErr) || syntaxErr.Reason != ReasonMissingTerminator {
+		t.Errorf("DecodeTokenDesc error = %v, expected a missing terminator", err)
+	}
+	if _, err := c.DecodeTokenDesc("7A1"); !errors.As(err, &syntaxErr) || syntaxErr.Offset != 1 {
+		t.Errorf("DecodeTokenDesc error = %v, expected a *SyntaxError at offset 1", err)
+	}
+}
+
+func TestSortednessDesc(t *testing.T) {
+	step := 0.01
+	prev := LessThanAny
+	c := new(Codec)
+	for i := 111111.0; i >= -111111.0; i-- {
+		str := fmt.Sprintf("%3f", i*step)
+		encoded, err := c.EncodeTokenDesc(str)
+		if err != nil {
+			t.Fatal("Encoding failed for", i)
+		}
+		if prev >= encoded {
+			t.Fatal("at", i*step, " ", prev, "is not smaller than", encoded)
+		}
+		prev = encoded
+	}
+	if prev >= GreaterThanAny {
+		t.Fatal(prev, "is not smaller than", GreaterThanAny)
+	}
+}
+
+func ExampleCodec_EncodeTokenDesc() {
+	c := new(Codec)
+
+	for _, input := range []string{"100", "9.99", "-5"} {
+		out, err := c.EncodeTokenDesc(input)
+		fmt.Printf("%q, %v\n", out, err)
+	}
+
+	// Output:
+	// "3wy~", <nil>
+	// "3yqqq~", <nil>
+	// "715", <nil>
+}
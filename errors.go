@@ -0,0 +1,114 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSyntax is returned when the input of a decoding function is not a valid Conust token.
+var ErrSyntax = errors.New("conust: invalid token")
+
+// ErrRange is returned when a decoded value does not fit into the requested type.
+var ErrRange = errors.New("conust: value out of range")
+
+// ErrFraction is returned when a token holding a non integer value is decoded into an integer type.
+var ErrFraction = errors.New("conust: value has a fractional part")
+
+// ErrPrecision is returned when a value can not be represented exactly in the requested type or format,
+// like a value with more fractional digits than the type can hold.
+var ErrPrecision = errors.New("conust: value can not be represented exactly")
+
+// ErrBase is returned when the requested base is not between 2 and 36.
+var ErrBase = errors.New("conust: invalid base")
+
+// ErrFormat is returned by DecodeTokenFormatted when the format has invalid settings.
+var ErrFormat = errors.New("conust: invalid format")
+
+// ErrKind is returned when an element of a tuple has an unknown kind.
+var ErrKind = errors.New("conust: invalid element kind")
+
+// ErrOption is returned by NewCodec when an option has an invalid value.
+var ErrOption = errors.New("conust: invalid codec option")
+
+// SyntaxErrorReason tells what kind of problem a SyntaxError reports.
+type SyntaxErrorReason int
+
+const (
+	// ReasonInvalidCharacter means that a byte is not valid at its position.
+	ReasonInvalidCharacter SyntaxErrorReason = iota + 1
+	// ReasonMultipleDecimalPoints means that a number has a second decimal point.
+	ReasonMultipleDecimalPoints
+	// ReasonInvalidSign means that a token does not start with a valid sign byte.
+	ReasonInvalidSign
+	// ReasonInvalidMagnitude means that the magnitude of a token contains a non digit byte.
+	ReasonInvalidMagnitude
+	// ReasonMissingTerminator means that a negative token does not end with the terminator.
+	ReasonMissingTerminator
+	// ReasonUnexpectedEnd means that a token ends before all of its parts are present.
+	ReasonUnexpectedEnd
+	// ReasonDigitOutOfBase means that a digit is not valid in the requested base.
+	ReasonDigitOutOfBase
+	// ReasonNonCanonicalMagnitude means that the magnitude of a token is not written the way the encoder writes it.
+	ReasonNonCanonicalMagnitude
+	// ReasonLeadingZero means that the significant digits of a token start with a zero.
+	ReasonLeadingZero
+	// ReasonTrailingZero means that the significant digits of a token end with a zero.
+	ReasonTrailingZero
+)
+
+var syntaxErrorReasonTexts = [...]string{
+	ReasonInvalidCharacter:      "invalid character",
+	ReasonMultipleDecimalPoints: "multiple decimal points",
+	ReasonInvalidSign:           "invalid sign",
+	ReasonInvalidMagnitude:      "invalid magnitude",
+	ReasonMissingTerminator:     "missing negative number terminator",
+	ReasonUnexpectedEnd:         "unexpected end of input",
+	ReasonDigitOutOfBase:        "digit out of base",
+	ReasonNonCanonicalMagnitude: "non-canonical magnitude",
+	ReasonLeadingZero:           "leading zero digit",
+	ReasonTrailingZero:          "trailing zero digit",
+}
+
+func (r SyntaxErrorReason) String() string {
+	if r > 0 && int(r) < len(syntaxErrorReasonTexts) {
+		return syntaxErrorReasonTexts[r]
+	}
+	return fmt.Sprintf("SyntaxErrorReason(%d)", int(r))
+}
+
+// SyntaxError describes why an input could not be encoded or decoded.
+// It matches ErrSyntax when checked with errors.Is.
+type SyntaxError struct {
+	// Offset is the position of the problem in the input in bytes.
+	Offset int
+	// Byte is the offending byte, or 0 if the input ended unexpectedly.
+	Byte   byte
+	Reason SyntaxErrorReason
+}
+
+func newSyntaxError(input string, offset int, reason SyntaxErrorReason) *SyntaxError {
+	e := &SyntaxError{Offset: offset, Reason: reason}
+	if offset < len(input) {
+		e.Byte = input[offset]
+	}
+	return e
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Reason == ReasonUnexpectedEnd {
+		return fmt.Sprintf("conust: %v at offset %d", e.Reason, e.Offset)
+	}
+	return fmt.Sprintf("conust: %v %q at offset %d", e.Reason, e.Byte, e.Offset)
+}
+
+// Is reports whether target is ErrSyntax.
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+// shift returns a copy of the error with the offset moved by delta.
+func (e *SyntaxError) shift(delta int) *SyntaxError {
+	shifted := *e
+	shifted.Offset += delta
+	return &shifted
+}
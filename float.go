@@ -0,0 +1,125 @@
+package conust
+
+import (
+	"math"
+	"strconv"
+)
+
+const exponentByte byte = 'e'
+
+// EncodeFloat64 turns the input float into a Conust token using the shortest decimal representation
+// that uniquely identifies the value. The order of the tokens is the same as the order of the floats,
+// and DecodeFloat64 restores the exact same value. Both zeros are encoded as zero.
+// Encoding fails for NaN and infinite values.
+func (c *Codec) EncodeFloat64(input float64) (out string, ok bool) {
+	return c.encodeFloat(input, 64)
+}
+
+// DecodeFloat64 turns a Conust token back into a float. The token must hold a decimal number.
+// If the token holds more digits than a float64 can represent the result is rounded to the nearest float.
+// ErrRange is returned if the value is too large to be represented as a float64.
+func (c *Codec) DecodeFloat64(input string) (out float64, err error) {
+	return c.decodeFloat(input, 64)
+}
+
+// encodeFloat encodes the input using the shortest decimal representation for the given bit size,
+// so that float32 values are not encoded with the digits of their float64 conversion.
+func (c *Codec) encodeFloat(input float64, bitSize int) (out string, ok bool) {
+	if math.IsNaN(input) || math.IsInf(input, 0) {
+		return "", false
+	}
+	if input == 0 {
+		return zeroOutput, true
+	}
+
+	var buf [32]byte
+	return c.encodeExponentFormat(strconv.AppendFloat(buf[:0], input, exponentByte, -1, bitSize)), true
+}
+
+func (c *Codec) decodeFloat(input string, bitSize int) (out float64, err error) {
+	if input == zeroOutput {
+		return 0, nil
+	}
+
+	t, err := c.parseToken(input)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := c.writeExponentFormat(input, t); err != nil {
+		return 0, err
+	}
+
+	out, err = strconv.ParseFloat(string(c.buf), bitSize)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return 0, ErrRange
+		}
+		return 0, ErrSyntax
+	}
+	return out, nil
+}
+
+// encodeExponentFormat turns a non-zero decimal number formatted as [-]d[.ddd]e(+|-)dd[d...] into a token.
+// This is the 'e' format of both strconv and math/big. The content of the input slice is modified.
+func (c *Codec) encodeExponentFormat(formatted []byte) string {
+	positive := formatted[0] != minusByte
+	if !positive {
+		formatted = formatted[1:]
+	}
+
+	exponentPos := 0
+	for formatted[exponentPos] != exponentByte {
+		exponentPos++
+	}
+
+	// the digits are made contiguous by moving the first one in place of the decimal point
+	digits := formatted[:exponentPos]
+	if len(digits) > 1 {
+		digits[1] = digits[0]
+		digits = digits[1:]
+	}
+	for digits[len(digits)-1] == digit0 {
+		digits = digits[:len(digits)-1]
+	}
+
+	exponentPositive := formatted[exponentPos+1] != minusByte
+	exponent := 0
+	for i := exponentPos + 2; i < len(formatted); i++ {
+		exponent = exponent*10 + digitToInt(formatted[i])
+	}
+
+	// d.ddd * 10^e has e+1 integer digits if e >= 0 and -e-1 leading fractional zeros otherwise
+	if exponentPositive {
+		return c.writeToken(positive, true, exponent+1, digits)
+	}
+	return c.writeToken(positive, false, exponent-1, digits)
+}
+
+// writeExponentFormat writes the value of a non-zero token into the buffer as [-]0.ddde[-]d...,
+// which is accepted by both strconv and math/big. It returns a *SyntaxError if the token has non decimal digits.
+func (c *Codec) writeExponentFormat(input string, t tokenLayout) error {
+	c.buf = c.emptyBuf()
+	if !t.positive {
+		c.buf = append(c.buf, minusByte)
+	}
+	c.buf = append(c.buf, digit0)
+	c.buf = append(c.buf, decimalPoint)
+	for i := t.sStartPos; i < t.sEndPos; i++ {
+		digit := input[i]
+		if !t.positive {
+			digit = reverseDigit(digit)
+		}
+		if digit > digit9 {
+			return newSyntaxError(input, i, ReasonDigitOutOfBase)
+		}
+		c.buf = append(c.buf, digit)
+	}
+	c.buf = append(c.buf, exponentByte)
+	if t.magnitudePositive {
+		c.buf = strconv.AppendInt(c.buf, int64(t.magnitude), 10)
+	} else {
+		c.buf = strconv.AppendInt(c.buf, int64(-t.magnitude), 10)
+	}
+	return nil
+}
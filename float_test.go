@@ -0,0 +1,130 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestCodec_Float64(t *testing.T) {
+	values := []float64{
+		0, 1, -1, 0.5, -0.5, 1.2, -1.2, 0.0012, -0.0012, 1200, -1200, 1e300, -1e300, 1.5e-300, -1.5e-300,
+		math.MaxFloat64, -math.MaxFloat64, math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64,
+		math.MaxInt64, math.Pi, -math.E, 0.1 + 0.2, 1e21, 123456789.987654321,
+	}
+
+	codec := new(Codec)
+	for _, v := range values {
+		t.Run(strconv.FormatFloat(v, 'g', -1, 64), func(t *testing.T) {
+			expected, _ := codec.EncodeToken(strconv.FormatFloat(v, 'f', -1, 64))
+			encoded, ok := codec.EncodeFloat64(v)
+
+			if !ok {
+				t.Fatalf("Encoding failed for: %v\n", v)
+			}
+
+			if encoded != expected {
+				t.Fatalf("Encoding expected: %v, got %v\n", expected, encoded)
+			}
+
+			decoded, err := codec.DecodeFloat64(encoded)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if math.Float64bits(decoded) != math.Float64bits(v) {
+				t.Fatalf("Decoding expected: %v, got %v\n", v, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_EncodeFloat64_Failure(t *testing.T) {
+	codec := new(Codec)
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		encoded, ok := codec.EncodeFloat64(v)
+
+		if ok || encoded != "" {
+			t.Fatalf("Encoding should have failed for: %v\n", v)
+		}
+	}
+}
+
+func TestCodec_DecodeFloat64_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "empty", input: "", err: ErrSyntax},
+		{name: "bad prefix", input: "2z412", err: ErrSyntax},
+		{name: "non decimal digit", input: "721a", err: ErrSyntax},
+		{name: "negative non decimal digit", input: "3xyp~", err: ErrSyntax},
+		{name: "too large", input: "7zzzzzzzzzz11", err: ErrRange},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeFloat64(i.input)
+
+			if !errors.Is(err, i.err) || decoded != 0 {
+				t.Fatalf("Decoding expected error %v, got %v, %v\n", i.err, decoded, err)
+			}
+		})
+	}
+
+	_, err := codec.DecodeFloat64("3xyx")
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 3 || syntaxErr.Reason != ReasonMissingTerminator {
+		t.Errorf("DecodeFloat64(%q) error = %v, expected %v at offset 3", "3xyx", err, ReasonMissingTerminator)
+	}
+}
+
+func TestFloat64Sortedness(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	values := make([]float64, 0, 100000)
+	for len(values) < cap(values) {
+		v := math.Float64frombits(r.Uint64())
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			values = append(values, v)
+		}
+	}
+	sort.Float64s(values)
+
+	c := new(Codec)
+	prev := LessThanAny
+	for i, v := range values {
+		encoded, ok := c.EncodeFloat64(v)
+		if !ok {
+			t.Fatal("Encoding failed for", v)
+		}
+		if prev >= encoded && !(i > 0 && values[i-1] == v) {
+			t.Fatal("at", v, " ", prev, "is not smaller than", encoded)
+		}
+		decoded, err := c.DecodeFloat64(encoded)
+		if err != nil || decoded != v {
+			t.Fatal("Decoding failed for", v, encoded, decoded, err)
+		}
+		prev = encoded
+	}
+}
+
+func ExampleCodec_EncodeFloat64() {
+	c := new(Codec)
+
+	out, ok := c.EncodeFloat64(1e300)
+	fmt.Printf("%q, %v\n", out, ok)
+
+	out, ok = c.EncodeFloat64(-0.0012)
+	fmt.Printf("%q, %v\n", out, ok)
+
+	// Output:
+	// "7zzzzzzzzt1", true
+	// "42yx~", true
+}
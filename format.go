@@ -0,0 +1,150 @@
+package conust
+
+// defaultGroupSize is the number of integer digits in a group when Format.GroupSize is not set.
+const defaultGroupSize = 3
+
+// Format describes how DecodeTokenFormatted writes a decimal number.
+type Format struct {
+	// MinIntegerDigits is the minimum number of integer digits, the missing ones are written as leading zeros.
+	// At least one integer digit is always written.
+	MinIntegerDigits int
+	// MinFractionDigits is the minimum number of fractional digits, the missing ones are written as trailing zeros.
+	MinFractionDigits int
+	// LimitFraction enables MaxFractionDigits, without it the fractional digits are not limited,
+	// so the zero Format writes the number unchanged.
+	LimitFraction bool
+	// MaxFractionDigits is the maximum number of fractional digits when LimitFraction is set, zero means
+	// rounding to an integer. Values with more fractional digits are rounded half away from zero.
+	// Setting both limits to the same value gives a fixed number of fractional digits.
+	MaxFractionDigits int
+	// PlusSign makes positive numbers and zero start with a '+' sign.
+	PlusSign bool
+	// GroupSeparator is written between the groups of the integer digits, 0 means no grouping.
+	GroupSeparator byte
+	// GroupSize is the number of integer digits in a group, 0 means 3.
+	GroupSize int
+	// DecimalSeparator is written between the integer and the fractional digits, 0 means '.'.
+	DecimalSeparator byte
+}
+
+// DecodeTokenFormatted turns a Conust token holding a decimal number into a string formatted according to
+// the given format, for example with a fixed number of fractional digits and thousands separators for display.
+// On failure it returns a *SyntaxError describing the problem, or ErrFormat if the format is invalid.
+func (c *Codec) DecodeTokenFormatted(input string, format Format) (out string, err error) {
+	if format.MinIntegerDigits < 0 || format.MinFractionDigits < 0 || format.GroupSize < 0 ||
+		(format.LimitFraction && (format.MaxFractionDigits < 0 || format.MinFractionDigits > format.MaxFractionDigits)) {
+		return "", ErrFormat
+	}
+	if input == "" {
+		return "", nil
+	}
+
+	positive := true
+	integerDigits := 0
+	c.scratch = c.emptyScratch()
+	if input != zeroOutput {
+		t, err := c.parseTokenDigits(input, 10)
+		if err != nil {
+			return "", err
+		}
+		positive = t.positive
+		integerDigits = t.magnitude
+		if !t.magnitudePositive {
+			integerDigits = -t.magnitude
+		}
+		for i := t.sStartPos; i < t.sEndPos; i++ {
+			c.scratch = append(c.scratch, c.significantDigit(input, t, i))
+		}
+	}
+
+	// the value is 0.digits * 10^integerDigits
+	digits := c.scratch
+	if format.LimitFraction && len(digits)-integerDigits > format.MaxFractionDigits {
+		digits, integerDigits = c.roundDigits(digits, integerDigits, integerDigits+format.MaxFractionDigits)
+	}
+	if len(digits) == 0 {
+		positive = true
+	}
+
+	c.buf = c.emptyBuf()
+	if !positive {
+		c.buf = append(c.buf, minusByte)
+	} else if format.PlusSign {
+		c.buf = append(c.buf, plusByte)
+	}
+
+	integerLength := integerDigits
+	if integerLength < format.MinIntegerDigits {
+		integerLength = format.MinIntegerDigits
+	}
+	if integerLength < 1 {
+		integerLength = 1
+	}
+	groupSize := format.GroupSize
+	if groupSize == 0 {
+		groupSize = defaultGroupSize
+	}
+	for i := 0; i < integerLength; i++ {
+		if format.GroupSeparator != 0 && i > 0 && (integerLength-i)%groupSize == 0 {
+			c.buf = append(c.buf, format.GroupSeparator)
+		}
+		c.buf = append(c.buf, digitAt(digits, integerDigits-integerLength+i))
+	}
+
+	fractionLength := len(digits) - integerDigits
+	if fractionLength < format.MinFractionDigits {
+		fractionLength = format.MinFractionDigits
+	}
+	if fractionLength > 0 {
+		if format.DecimalSeparator != 0 {
+			c.buf = append(c.buf, format.DecimalSeparator)
+		} else {
+			c.buf = append(c.buf, decimalPoint)
+		}
+		for i := 0; i < fractionLength; i++ {
+			c.buf = append(c.buf, digitAt(digits, integerDigits+i))
+		}
+	}
+
+	return string(c.buf), nil
+}
+
+// roundDigits rounds the decimal number 0.digits * 10^integerDigits half away from zero, so that only the
+// first keep digits remain. The result has no trailing zeros, and zero is returned without digits.
+func (c *Codec) roundDigits(digits []byte, integerDigits int, keep int) (rounded []byte, roundedIntegerDigits int) {
+	if keep < 0 {
+		return digits[:0], 0
+	}
+
+	roundUp := digits[keep] >= digit0+5
+	digits = digits[:keep]
+	if roundUp {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == digit9; i-- {
+			digits[i] = digit0
+		}
+		if i >= 0 {
+			digits[i]++
+		} else {
+			// all the digits were nines, so the carry creates a new leading digit
+			digits = append(digits[:0], digit1)
+			integerDigits++
+		}
+	}
+
+	for len(digits) > 0 && digits[len(digits)-1] == digit0 {
+		digits = digits[:len(digits)-1]
+	}
+	if len(digits) == 0 {
+		return digits, 0
+	}
+	return digits, integerDigits
+}
+
+// digitAt returns the i-th digit of a number, which is zero outside of the stored digits.
+func digitAt(digits []byte, i int) byte {
+	if i < 0 || i >= len(digits) {
+		return digit0
+	}
+	return digits[i]
+}
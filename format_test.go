@@ -0,0 +1,99 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodec_DecodeTokenFormatted(t *testing.T) {
+	price := Format{MinFractionDigits: 2, LimitFraction: true, MaxFractionDigits: 2, GroupSeparator: ','}
+
+	testCases := []struct {
+		name   string
+		input  string
+		format Format
+		output string
+	}{
+		{name: "empty", input: "", format: price, output: ""},
+		{name: "zero", input: "0", format: price, output: "0.00"},
+		{name: "unlimited", input: "-54321.12345", format: Format{}, output: "-54321.12345"},
+		{name: "unlimited above min", input: "2.125", format: Format{MinFractionDigits: 2}, output: "2.125"},
+		{name: "round to integer", input: "2.5", format: Format{LimitFraction: true}, output: "3"},
+		{name: "round negative to integer", input: "-2.5", format: Format{LimitFraction: true}, output: "-3"},
+		{name: "round down", input: "2.4999", format: Format{LimitFraction: true}, output: "2"},
+		{name: "price", input: "1234567.891", format: price, output: "1,234,567.89"},
+		{name: "price rounded up", input: "999.995", format: price, output: "1,000.00"},
+		{name: "negative price", input: "-1234.5", format: price, output: "-1,234.50"},
+		{name: "small price", input: "0.001", format: price, output: "0.00"},
+		{name: "negative rounded to zero", input: "-0.001", format: price, output: "0.00"},
+		{name: "rounded to the first digit", input: "0.006", format: price, output: "0.01"},
+		{name: "rounded far below the digits", input: "0.000009", format: price, output: "0.00"},
+		{name: "large integer", input: "1e6", format: price, output: "1,000,000.00"},
+		{name: "min integer digits", input: "7.5", format: Format{MinIntegerDigits: 3}, output: "007.5"},
+		{name: "min integer digits of fraction", input: "0.25", format: Format{MinIntegerDigits: 2}, output: "00.25"},
+		{name: "grouped leading zeros", input: "5", format: Format{MinIntegerDigits: 5, GroupSeparator: ' '}, output: "00 005"},
+		{name: "group size", input: "12345678", format: Format{GroupSeparator: '\'', GroupSize: 4}, output: "1234'5678"},
+		{name: "min fraction digits", input: "3.1", format: Format{MinFractionDigits: 3}, output: "3.100"},
+		{name: "max fraction digits", input: "3.14159", format: Format{LimitFraction: true, MaxFractionDigits: 3}, output: "3.142"},
+		{name: "trailing zeros after rounding", input: "3.1996", format: Format{LimitFraction: true, MaxFractionDigits: 3}, output: "3.2"},
+		{name: "plus sign", input: "3.5", format: Format{PlusSign: true}, output: "+3.5"},
+		{name: "plus sign of zero", input: "0", format: Format{PlusSign: true}, output: "+0"},
+		{name: "plus sign of negative", input: "-3.5", format: Format{PlusSign: true}, output: "-3.5"},
+		{
+			name:   "decimal comma",
+			input:  "-1234567.5",
+			format: Format{MinFractionDigits: 2, LimitFraction: true, MaxFractionDigits: 2, GroupSeparator: '.', DecimalSeparator: ','},
+			output: "-1.234.567,50",
+		},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, ok := c.EncodeScientificToken(tc.input)
+			if !ok {
+				t.Fatalf("EncodeScientificToken(%q) failed", tc.input)
+			}
+			out, err := c.DecodeTokenFormatted(token, tc.format)
+			if err != nil || out != tc.output {
+				t.Errorf("DecodeTokenFormatted(%q) = %q, %v, expected %q", token, out, err, tc.output)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeTokenFormatted_Failure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		format Format
+		err    error
+	}{
+		{name: "invalid token", input: "3yy", format: Format{}, err: ErrSyntax},
+		{name: "non decimal token", input: "72ff8", format: Format{}, err: ErrSyntax},
+		{name: "min above max", input: "711", format: Format{MinFractionDigits: 3, LimitFraction: true, MaxFractionDigits: 2}, err: ErrFormat},
+		{name: "negative max", input: "711", format: Format{LimitFraction: true, MaxFractionDigits: -1}, err: ErrFormat},
+		{name: "negative min", input: "711", format: Format{MinIntegerDigits: -1}, err: ErrFormat},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := c.DecodeTokenFormatted(tc.input, tc.format); !errors.Is(err, tc.err) {
+				t.Errorf("DecodeTokenFormatted(%q) error = %v, expected %v", tc.input, err, tc.err)
+			}
+		})
+	}
+}
+
+func ExampleCodec_DecodeTokenFormatted() {
+	c := new(Codec)
+	token, _ := c.EncodeToken("1234.5")
+
+	out, err := c.DecodeTokenFormatted(token, Format{MinFractionDigits: 2, LimitFraction: true, MaxFractionDigits: 2, GroupSeparator: ','})
+	fmt.Printf("%q, %v\n", out, err)
+
+	// Output:
+	// "1,234.50", <nil>
+}
@@ -0,0 +1,123 @@
+package conust
+
+import (
+	"math/big"
+	"unsafe"
+)
+
+// Number is the set of the built-in numeric types, and the types defined on them, that can be
+// encoded with Encode and decoded with Decode.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+type numberKind int
+
+const (
+	signedKind numberKind = iota
+	unsignedKind
+	float32Kind
+	float64Kind
+)
+
+// kindOf tells the kind of T apart by arithmetic, so that defined types like time.Duration
+// are handled the same way as their underlying type.
+func kindOf[T Number]() numberKind {
+	var zero T
+	one := zero + 1
+	if one/2 != zero {
+		if unsafe.Sizeof(zero) == 4 {
+			return float32Kind
+		}
+		return float64Kind
+	}
+	if zero-one < zero {
+		return signedKind
+	}
+	return unsignedKind
+}
+
+// Encode turns the input number into a Conust token. Integers are encoded the same way as by EncodeInt64
+// and EncodeUint64, floats are encoded using the shortest decimal representation of their own precision.
+// Encoding fails for NaN and infinite values. It is safe for concurrent use.
+func Encode[T Number](input T) (out string, ok bool) {
+	c := getCodec()
+	defer putCodec(c)
+	switch kindOf[T]() {
+	case signedKind:
+		return c.EncodeInt64(int64(input)), true
+	case unsignedKind:
+		return c.EncodeUint64(uint64(input)), true
+	case float32Kind:
+		return c.encodeFloat(float64(input), 32)
+	default:
+		return c.encodeFloat(float64(input), 64)
+	}
+}
+
+// Decode turns a Conust token back into a number of type T. The token must hold a decimal number.
+// ErrRange is returned if the value does not fit into T, ErrFraction if T is an integer type and the value
+// has a fractional part, and ErrPrecision if T is a float type that can not hold the value exactly, unless the token is the encoding
+// of the shortest representation of the decoded float, like the token of 0.1.
+// It is safe for concurrent use.
+func Decode[T Number](input string) (out T, err error) {
+	c := getCodec()
+	defer putCodec(c)
+	return decodeNumber[T](c, input)
+}
+
+// decodeNumber works like Decode, but it uses the given codec, so that its settings apply.
+func decodeNumber[T Number](c *Codec, input string) (out T, err error) {
+	switch kindOf[T]() {
+	case signedKind:
+		v, err := c.DecodeInt64(input)
+		if err != nil {
+			return 0, err
+		}
+		if int64(T(v)) != v {
+			return 0, ErrRange
+		}
+		return T(v), nil
+	case unsignedKind:
+		v, err := c.DecodeUint64(input)
+		if err != nil {
+			return 0, err
+		}
+		if uint64(T(v)) != v {
+			return 0, ErrRange
+		}
+		return T(v), nil
+	}
+
+	bitSize := 64
+	if kindOf[T]() == float32Kind {
+		bitSize = 32
+	}
+	v, err := c.decodeFloat(input, bitSize)
+	if err != nil {
+		return 0, err
+	}
+	// the shortest representation of v is accepted as well, so that Encode and Decode round trip
+	// for values like 0.1, which have no exact float representation
+	if encoded, _ := c.encodeFloat(v, bitSize); encoded != input && !c.isExactFloat(input, v) {
+		return 0, ErrPrecision
+	}
+	return T(v), nil
+}
+
+// isExactFloat reports whether the value of the valid decimal token is exactly v.
+func (c *Codec) isExactFloat(input string, v float64) bool {
+	if input == zeroOutput {
+		return v == 0
+	}
+	if v == 0 {
+		return false
+	}
+
+	t, _ := c.parseToken(input)
+	c.writeExponentFormat(input, t)
+	exact, _ := new(big.Rat).SetString(string(c.buf))
+	return exact.Cmp(new(big.Rat).SetFloat64(v)) == 0
+}
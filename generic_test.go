@@ -0,0 +1,127 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func testEncodeDecode[T Number](t *testing.T, input T, encoded string) {
+	t.Helper()
+
+	out, ok := Encode(input)
+
+	if !ok {
+		t.Fatalf("Encoding failed for: %v\n", input)
+	}
+
+	if out != encoded {
+		t.Fatalf("Encoding expected: %v, got %v\n", encoded, out)
+	}
+
+	decoded, err := Decode[T](out)
+
+	if err != nil {
+		t.Fatalf("Decoding failed for: %v: %v\n", out, err)
+	}
+
+	if decoded != input {
+		t.Fatalf("Decoding expected: %v, got %v\n", input, decoded)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	testEncodeDecode(t, int8(math.MinInt8), "3wyxr~")
+	testEncodeDecode(t, int16(math.MaxInt16), "7532767")
+	testEncodeDecode(t, int32(-1200), "3vyx~")
+	testEncodeDecode(t, int64(math.MinInt64), "3gqxxwwsxzwtruvssurzr~")
+	testEncodeDecode(t, int(86400), "75864")
+	testEncodeDecode(t, uint8(math.MaxUint8), "73255")
+	testEncodeDecode(t, uint16(0), "5")
+	testEncodeDecode(t, uint32(math.MaxUint32), "7a4294967295")
+	testEncodeDecode(t, uint64(math.MaxUint64), "7k18446744073709551615")
+	testEncodeDecode(t, uint(1), "711")
+	testEncodeDecode(t, uintptr(10), "721")
+	testEncodeDecode(t, float32(0.1), "6z1")
+	testEncodeDecode(t, float32(-1.5), "3yyu~")
+	testEncodeDecode(t, float64(0.1), "6z1")
+	testEncodeDecode(t, math.MaxFloat64, "7zzzzzzzzz317976931348623157")
+	testEncodeDecode(t, 2*time.Second, "7a2")
+}
+
+func TestEncode_Failure(t *testing.T) {
+	if out, ok := Encode(math.NaN()); ok || out != "" {
+		t.Fatal("Encoding should have failed for NaN")
+	}
+	if out, ok := Encode(float32(math.Inf(1))); ok || out != "" {
+		t.Fatal("Encoding should have failed for infinity")
+	}
+}
+
+func TestDecode_ExactFloat(t *testing.T) {
+	c := new(Codec)
+	token := c.EncodeUint64(1 << 60)
+	if v, err := Decode[float64](token); err != nil || v != 1<<60 {
+		t.Fatalf("Decode[float64](%q) = %v, %v, expected %v", token, v, err, float64(1<<60))
+	}
+	token, _ = Encode(uint64(1 << 60))
+	if v, err := Decode[float64](token); err != nil || v != 1<<60 {
+		t.Fatalf("Decode[float64](%q) = %v, %v, expected %v", token, v, err, float64(1<<60))
+	}
+	token, _ = Encode(uint32(1<<32 - 256))
+	if v, err := Decode[float32](token); err != nil || v != 1<<32-256 {
+		t.Fatalf("Decode[float32](%q) = %v, %v, expected %v", token, v, err, float32(1<<32-256))
+	}
+	token, _ = Encode(0.1)
+	if v, err := Decode[float64](token); err != nil || v != 0.1 {
+		t.Fatalf("Decode[float64](%q) = %v, %v, expected %v", token, v, err, 0.1)
+	}
+	token, _ = Encode(uint64(1<<60 + 1))
+	if _, err := Decode[float64](token); err != ErrPrecision {
+		t.Fatalf("Decode[float64](%q) error = %v, expected %v", token, err, ErrPrecision)
+	}
+}
+
+func TestDecode_Failure(t *testing.T) {
+	if _, err := Decode[int8]("73128"); err != ErrRange {
+		t.Fatalf("expected %v, got %v", ErrRange, err)
+	}
+	if _, err := Decode[int8]("3wyxq~"); err != ErrRange {
+		t.Fatalf("expected %v, got %v", ErrRange, err)
+	}
+	if _, err := Decode[uint8]("73256"); err != ErrRange {
+		t.Fatalf("expected %v, got %v", ErrRange, err)
+	}
+	if _, err := Decode[uint32]("3yy~"); err != ErrRange {
+		t.Fatalf("expected %v, got %v", ErrRange, err)
+	}
+	if _, err := Decode[int]("7115"); err != ErrFraction {
+		t.Fatalf("expected %v, got %v", ErrFraction, err)
+	}
+	if _, err := Decode[float32]("7zb1"); err != ErrRange {
+		t.Fatalf("expected %v, got %v", ErrRange, err)
+	}
+	if _, err := Decode[float32]("6z100000001"); err != ErrPrecision {
+		t.Fatalf("expected %v, got %v", ErrPrecision, err)
+	}
+	if _, err := Decode[float64]("6z1234567890123456789"); err != ErrPrecision {
+		t.Fatalf("expected %v, got %v", ErrPrecision, err)
+	}
+	if _, err := Decode[float64]("2z1"); !errors.Is(err, ErrSyntax) {
+		t.Fatalf("expected %v, got %v", ErrSyntax, err)
+	}
+}
+
+func ExampleEncode() {
+	out, ok := Encode(uint8(200))
+	fmt.Printf("%q, %v\n", out, ok)
+
+	out, ok = Encode(float32(-0.0012))
+	fmt.Printf("%q, %v\n", out, ok)
+
+	// Output:
+	// "732", true
+	// "42yx~", true
+}
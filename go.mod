@@ -1,3 +1,3 @@
 module github.com/koalamer/conust/v2
 
-go 1.12
+go 1.18
@@ -0,0 +1,161 @@
+package conust
+
+import "math"
+
+// maxUint64Digits is the number of decimal digits of math.MaxUint64.
+const maxUint64Digits = 20
+
+// EncodeInt64 turns the input integer into a Conust token. The output is the same as the output of
+// EncodeToken for the decimal representation of the number.
+func (c *Codec) EncodeInt64(input int64) string {
+	if input < 0 {
+		// for math.MinInt64 the negation overflows, but the conversion still yields the right value
+		return c.encodeUint64(false, uint64(-input))
+	}
+	return c.encodeUint64(true, uint64(input))
+}
+
+// DecodeInt64 turns a Conust token back into an integer. The token must hold a decimal integer.
+// ErrRange is returned if the value does not fit into an int64, and ErrFraction if it has a fractional part.
+func (c *Codec) DecodeInt64(input string) (out int64, err error) {
+	positive, abs, err := c.decodeUint64(input)
+	if err != nil {
+		return 0, err
+	}
+	if positive {
+		if abs > math.MaxInt64 {
+			return 0, ErrRange
+		}
+		return int64(abs), nil
+	}
+	if abs > 1<<63 {
+		return 0, ErrRange
+	}
+	return -int64(abs), nil
+}
+
+// EncodeUint64 turns the input unsigned integer into a Conust token. The output is the same as the output of
+// EncodeToken for the decimal representation of the number.
+func (c *Codec) EncodeUint64(input uint64) string {
+	return c.encodeUint64(true, input)
+}
+
+// DecodeUint64 turns a Conust token back into an unsigned integer. The token must hold a decimal integer.
+// ErrRange is returned if the value is negative or greater than math.MaxUint64, and ErrFraction
+// if it has a fractional part.
+func (c *Codec) DecodeUint64(input string) (out uint64, err error) {
+	positive, abs, err := c.decodeUint64(input)
+	if err != nil {
+		return 0, err
+	}
+	if !positive {
+		return 0, ErrRange
+	}
+	return abs, nil
+}
+
+func (c *Codec) encodeUint64(positive bool, abs uint64) string {
+	return c.encodeFixedPoint(positive, abs, 0, 0)
+}
+
+func (c *Codec) decodeUint64(input string) (positive bool, abs uint64, err error) {
+	positive, abs, _, err = c.decodeFixedPoint(input, 0)
+	if err == ErrPrecision {
+		return false, 0, ErrFraction
+	}
+	return positive, abs, err
+}
+
+// encodeFixedPoint encodes the decimal number intPart.frac, where frac holds exactly fracDigits digits
+// including its leading zeros.
+func (c *Codec) encodeFixedPoint(positive bool, intPart uint64, frac uint64, fracDigits int) string {
+	var buf [2 * maxUint64Digits]byte
+	sEndPos := len(buf)
+	sStartPos := sEndPos
+	for i := 0; i < fracDigits; i++ {
+		sStartPos--
+		buf[sStartPos] = intToDigit(int(frac % 10))
+		frac /= 10
+	}
+	intDigitCount := 0
+	for ; intPart > 0; intPart /= 10 {
+		sStartPos--
+		buf[sStartPos] = intToDigit(int(intPart % 10))
+		intDigitCount++
+	}
+
+	for sStartPos < sEndPos && buf[sStartPos] == digit0 {
+		sStartPos++
+	}
+	if sStartPos == sEndPos {
+		return zeroOutput
+	}
+	for buf[sEndPos-1] == digit0 {
+		sEndPos--
+	}
+
+	if intDigitCount > 0 {
+		return c.writeToken(positive, true, intDigitCount, buf[sStartPos:sEndPos])
+	}
+	leadingZeroCount := sStartPos - (len(buf) - fracDigits)
+	return c.writeToken(positive, false, leadingZeroCount, buf[sStartPos:sEndPos])
+}
+
+// decodeFixedPoint decodes a token holding a decimal number into its integer part and its fractional part
+// of exactly fracDigits digits. ErrPrecision is returned if the value has more fractional digits.
+func (c *Codec) decodeFixedPoint(input string, fracDigits int) (positive bool, intPart uint64, frac uint64, err error) {
+	if input == zeroOutput {
+		return true, 0, 0, nil
+	}
+
+	t, err := c.parseToken(input)
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	significantPartLength := t.sEndPos - t.sStartPos
+	intDigitCount := t.magnitude
+	if !t.magnitudePositive {
+		intDigitCount = -t.magnitude
+	}
+	if significantPartLength-intDigitCount > fracDigits {
+		return false, 0, 0, ErrPrecision
+	}
+	if intDigitCount > maxUint64Digits {
+		return false, 0, 0, ErrRange
+	}
+
+	for i := 0; i < intDigitCount; i++ {
+		digitValue, ok := c.decimalDigitAt(input, t, i)
+		if !ok {
+			return false, 0, 0, newSyntaxError(input, t.sStartPos+i, ReasonDigitOutOfBase)
+		}
+		if intPart > (math.MaxUint64-digitValue)/10 {
+			return false, 0, 0, ErrRange
+		}
+		intPart = intPart*10 + digitValue
+	}
+	for i := 0; i < fracDigits; i++ {
+		digitValue, ok := c.decimalDigitAt(input, t, intDigitCount+i)
+		if !ok {
+			return false, 0, 0, newSyntaxError(input, t.sStartPos+intDigitCount+i, ReasonDigitOutOfBase)
+		}
+		frac = frac*10 + digitValue
+	}
+
+	return t.positive, intPart, frac, nil
+}
+
+// decimalDigitAt returns the value of the i-th significant digit of the token, which is zero outside
+// of the stored digits. It reports false if the digit is not a decimal digit.
+func (c *Codec) decimalDigitAt(input string, t tokenLayout, i int) (digitValue uint64, ok bool) {
+	if i < 0 || i >= t.sEndPos-t.sStartPos {
+		return 0, true
+	}
+	if t.positive {
+		digitValue = uint64(digitToInt(input[t.sStartPos+i]))
+	} else {
+		digitValue = uint64(reversedDigitToInt(input[t.sStartPos+i]))
+	}
+	return digitValue, digitValue <= 9
+}
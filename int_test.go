@@ -0,0 +1,143 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestCodec_Int64(t *testing.T) {
+	values := []int64{
+		0, 1, -1, 9, -9, 10, -10, 12, -12, 1200, -1200, 86400, 100000, -100000,
+		1234567890123, -1234567890123, math.MaxInt32, math.MinInt32,
+		math.MaxInt64, math.MinInt64, math.MaxInt64 - 1, math.MinInt64 + 1,
+	}
+
+	codec := new(Codec)
+	for _, v := range values {
+		t.Run(strconv.FormatInt(v, 10), func(t *testing.T) {
+			expected, _ := codec.EncodeToken(strconv.FormatInt(v, 10))
+			encoded := codec.EncodeInt64(v)
+
+			if encoded != expected {
+				t.Fatalf("Encoding expected: %v, got %v\n", expected, encoded)
+			}
+
+			decoded, err := codec.DecodeInt64(encoded)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if decoded != v {
+				t.Fatalf("Decoding expected: %v, got %v\n", v, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeInt64_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "empty", input: "", err: ErrSyntax},
+		{name: "bad prefix", input: "2z412", err: ErrSyntax},
+		{name: "no negative terminator", input: "3xyx", err: ErrSyntax},
+		{name: "non decimal digit", input: "721a", err: ErrSyntax},
+		{name: "fraction", input: "7112", err: ErrFraction},
+		{name: "small fraction", input: "6z12", err: ErrFraction},
+		{name: "negative fraction", input: "40yx~", err: ErrFraction},
+		{name: "above max", input: "7j9223372036854775808", err: ErrRange},
+		{name: "below min", input: "3gqxxwwsxzwtruvssurzq~", err: ErrRange},
+		{name: "too many digits", input: "7z011", err: ErrRange},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeInt64(i.input)
+
+			if !errors.Is(err, i.err) || decoded != 0 {
+				t.Fatalf("Decoding expected error %v, got %v, %v\n", i.err, decoded, err)
+			}
+		})
+	}
+
+	// the position of the problem is kept
+	_, err := codec.DecodeInt64("721a")
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 3 || syntaxErr.Reason != ReasonDigitOutOfBase {
+		t.Errorf("DecodeInt64(%q) error = %v, expected %v at offset 3", "721a", err, ReasonDigitOutOfBase)
+	}
+}
+
+func TestCodec_Uint64(t *testing.T) {
+	values := []uint64{
+		0, 1, 9, 10, 12, 1200, 86400, 100000, 1234567890123, math.MaxUint32,
+		math.MaxInt64, math.MaxInt64 + 1, math.MaxUint64 - 1, math.MaxUint64, 10000000000000000000,
+	}
+
+	codec := new(Codec)
+	for _, v := range values {
+		t.Run(strconv.FormatUint(v, 10), func(t *testing.T) {
+			expected, _ := codec.EncodeToken(strconv.FormatUint(v, 10))
+			encoded := codec.EncodeUint64(v)
+
+			if encoded != expected {
+				t.Fatalf("Encoding expected: %v, got %v\n", expected, encoded)
+			}
+
+			decoded, err := codec.DecodeUint64(encoded)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if decoded != v {
+				t.Fatalf("Decoding expected: %v, got %v\n", v, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeUint64_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "empty", input: "", err: ErrSyntax},
+		{name: "non decimal digit", input: "721a", err: ErrSyntax},
+		{name: "negative", input: "3yy~", err: ErrRange},
+		{name: "fraction", input: "7112", err: ErrFraction},
+		{name: "negative fraction", input: "40yx~", err: ErrFraction},
+		{name: "above max", input: "7k18446744073709551616", err: ErrRange},
+		{name: "too many digits", input: "7l1", err: ErrRange},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeUint64(i.input)
+
+			if !errors.Is(err, i.err) || decoded != 0 {
+				t.Fatalf("Decoding expected error %v, got %v, %v\n", i.err, decoded, err)
+			}
+		})
+	}
+}
+
+func ExampleCodec_EncodeInt64() {
+	c := new(Codec)
+
+	fmt.Printf("%q\n", c.EncodeInt64(86400))
+	fmt.Printf("%q\n", c.EncodeInt64(-1200))
+
+	// Output:
+	// "75864"
+	// "3vyx~"
+}
@@ -0,0 +1,59 @@
+package conust
+
+import "strings"
+
+// Every token of a list is followed by listSeparator, which sorts before the digits, so that a token
+// sorts before the tokens it is the prefix of. listTerminator sorts before the sign bytes, so that a list
+// sorts before the longer lists it is the prefix of, even if the key continues after the list.
+const (
+	listSeparator  byte = ','
+	listTerminator byte = '!'
+)
+
+// EncodeList encodes a list of numbers, given in the format accepted by EncodeToken, into a single key.
+// The keys sort like the lists: by their first number, then by their second, and so on, while a list sorts
+// before the longer lists it is the prefix of, so that [1 2] < [1 2 0] < [1 3]. The list is closed by
+// a terminator, so other data can follow it in a key without affecting the order.
+// If a number can not be encoded, a *SyntaxError is returned with the offset counted within that number.
+func (c *Codec) EncodeList(numbers ...string) (out string, err error) {
+	c.buf = c.emptyBuf()
+	for _, number := range numbers {
+		if number == "" {
+			return "", newSyntaxError(number, 0, ReasonUnexpectedEnd)
+		}
+		if err := c.appendTokenBase(number, maxBase); err != nil {
+			return "", err
+		}
+		c.buf = append(c.buf, listSeparator)
+	}
+	c.buf = append(c.buf, listTerminator)
+	return string(c.buf), nil
+}
+
+// DecodeList turns a key created by EncodeList back into the list of numbers. The numbers are restored
+// in the form DecodeToken produces. The input may continue after the list, n is the length of the list
+// including its terminator, so the data following it starts at input[n:].
+// On failure it returns a *SyntaxError describing the problem.
+func (c *Codec) DecodeList(input string) (numbers []string, n int, err error) {
+	pos := 0
+	for pos < len(input) && input[pos] != listTerminator {
+		end := strings.IndexByte(input[pos:], listSeparator)
+		if end < 0 {
+			return nil, 0, newSyntaxError(input, len(input), ReasonUnexpectedEnd)
+		}
+		if end == 0 {
+			return nil, 0, newSyntaxError(input, pos, ReasonInvalidCharacter)
+		}
+		c.buf = c.emptyBuf()
+		if err := c.appendDecodedBase(input[pos:pos+end], maxBase); err != nil {
+			return nil, 0, err.(*SyntaxError).shift(pos)
+		}
+		numbers = append(numbers, string(c.buf))
+		pos += end + 1
+	}
+
+	if pos == len(input) {
+		return nil, 0, newSyntaxError(input, pos, ReasonUnexpectedEnd)
+	}
+	return numbers, pos + 1, nil
+}
@@ -0,0 +1,132 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCodec_List(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   []string
+		encoded string
+		decoded []string
+	}{
+		{name: "empty", input: nil, encoded: "!", decoded: nil},
+		{name: "single", input: []string{"1"}, encoded: "711,!", decoded: []string{"1"}},
+		{name: "version", input: []string{"1", "02", "0"}, encoded: "711,712,5,!", decoded: []string{"1", "2", "0"}},
+		{name: "coordinates", input: []string{"-3.14", "0.000125"}, encoded: "3ywyv~,6w125,!", decoded: []string{"-3.14", "0.000125"}},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := c.EncodeList(tc.input...)
+			if err != nil || encoded != tc.encoded {
+				t.Fatalf("EncodeList(%q) = %q, %v, expected %q", tc.input, encoded, err, tc.encoded)
+			}
+			decoded, n, err := c.DecodeList(encoded)
+			if err != nil || !reflect.DeepEqual(decoded, tc.decoded) || n != len(encoded) {
+				t.Fatalf("DecodeList(%q) = %q, %d, %v, expected %q", encoded, decoded, n, err, tc.decoded)
+			}
+
+			// the key can continue after the list
+			decoded, n, err = c.DecodeList(encoded + "711,!x")
+			if err != nil || !reflect.DeepEqual(decoded, tc.decoded) || n != len(encoded) {
+				t.Fatalf("DecodeList of %q with trailing data = %q, %d, %v, expected %q, %d", encoded, decoded, n, err, tc.decoded, len(encoded))
+			}
+		})
+	}
+}
+
+func TestCodec_List_Failure(t *testing.T) {
+	c := new(Codec)
+
+	var syntaxErr *SyntaxError
+	if _, err := c.EncodeList("1", "1.2.3"); !errors.As(err, &syntaxErr) || syntaxErr.Offset != 3 {
+		t.Errorf("EncodeList error = %v, expected a *SyntaxError at offset 3", err)
+	}
+	if _, err := c.EncodeList("1", ""); !errors.As(err, &syntaxErr) || syntaxErr.Reason != ReasonUnexpectedEnd {
+		t.Errorf("EncodeList error = %v, expected an unexpected end", err)
+	}
+
+	testCases := []struct {
+		name   string
+		input  string
+		reason SyntaxErrorReason
+		offset int
+	}{
+		{name: "empty", input: "", reason: ReasonUnexpectedEnd, offset: 0},
+		{name: "missing terminator", input: "711,", reason: ReasonUnexpectedEnd, offset: 4},
+		{name: "missing separator", input: "711!", reason: ReasonUnexpectedEnd, offset: 4},
+		{name: "empty token", input: "711,,!", reason: ReasonInvalidCharacter, offset: 4},
+		{name: "invalid token", input: "711,3yy,!", reason: ReasonMissingTerminator, offset: 6},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := c.DecodeList(tc.input)
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("DecodeList(%q) error = %v, expected a *SyntaxError", tc.input, err)
+			}
+			if syntaxErr.Reason != tc.reason || syntaxErr.Offset != tc.offset {
+				t.Errorf("DecodeList(%q) error = %v, expected %v at offset %d", tc.input, err, tc.reason, tc.offset)
+			}
+		})
+	}
+}
+
+func TestListSortedness(t *testing.T) {
+	// the lists are listed in their expected order
+	lists := [][]string{
+		{},
+		{"-10"},
+		{"-1.5"},
+		{"-1"},
+		{"-1", "5"},
+		{"0"},
+		{"1"},
+		{"1", "-1"},
+		{"1", "2"},
+		{"1", "2", "0"},
+		{"1", "2", "0", "1"},
+		{"1", "2", "1"},
+		{"1", "2.5"},
+		{"1", "3"},
+		{"1", "10"},
+		{"1.1"},
+		{"2"},
+	}
+
+	c := new(Codec)
+	prev := ""
+	for i, list := range lists {
+		encoded, err := c.EncodeList(list...)
+		if err != nil {
+			t.Fatal("Encoding failed for", list)
+		}
+		// the key continues after the list, which must not affect the order
+		encoded += "~"
+		if i > 0 && prev >= encoded {
+			t.Fatalf("list %d %v: %q is not smaller than %q", i, list, prev, encoded)
+		}
+		prev = encoded
+	}
+}
+
+func ExampleCodec_EncodeList() {
+	c := new(Codec)
+
+	key, err := c.EncodeList("1", "2", "0")
+	fmt.Printf("%q, %v\n", key, err)
+
+	// other data can follow the list in the key
+	key += "rest"
+	numbers, n, err := c.DecodeList(key)
+	fmt.Printf("%q, %q, %v\n", numbers, key[n:], err)
+
+	// Output:
+	// "711,712,5,!", <nil>
+	// ["1" "2" "0"], "rest", <nil>
+}
@@ -0,0 +1,216 @@
+package conust
+
+import "strings"
+
+// Option configures a Codec created by NewCodec.
+type Option func(*codecConfig)
+
+type codecConfig struct {
+	decimalPoint byte
+	terminator   byte
+	separator    byte
+	alphabet     string
+	upperCase    bool
+	anyCase      bool
+}
+
+// WithDecimalPoint sets the decimal point of the numbers accepted by the encoding and produced by the decoding
+// functions. The default is '.'. It must not be a sign or a digit of the alphabet.
+func WithDecimalPoint(b byte) Option {
+	return func(cfg *codecConfig) {
+		cfg.decimalPoint = b
+	}
+}
+
+// WithTerminator sets the byte closing the tokens of negative numbers. The default is '~'.
+// It must be greater than 'z', otherwise the tokens would not sort properly.
+func WithTerminator(b byte) Option {
+	return func(cfg *codecConfig) {
+		cfg.terminator = b
+	}
+}
+
+// WithSeparator sets the byte EncodeMixedText puts around the tokens. The default is ' '.
+// It must be greater than 0 and less than '0', otherwise the strings would not sort properly.
+func WithSeparator(b byte) Option {
+	return func(cfg *codecConfig) {
+		cfg.separator = b
+	}
+}
+
+// WithAlphabet sets the digits of the numbers in ascending order of their value. The length of the alphabet
+// is the highest base the codec accepts, it must be between 2 and 36. The default is "0-9a-z". Tokens holding
+// digits above it can not be decoded into numbers, and EncodeTokenBase, DecodeTokenBase and the compact
+// and rebase functions return ErrBase for a higher base.
+// The alphabet only affects the numbers, the tokens always use the default digits, so that they sort properly
+// regardless of the order of the characters of the alphabet.
+func WithAlphabet(alphabet string) Option {
+	return func(cfg *codecConfig) {
+		cfg.alphabet = alphabet
+	}
+}
+
+// WithUpperCase makes the codec use the uppercase version of the alphabet, so that the letter digits
+// of the numbers are expected and produced in uppercase.
+func WithUpperCase() Option {
+	return func(cfg *codecConfig) {
+		cfg.upperCase = true
+	}
+}
+
+// WithAnyCase makes the encoding functions accept the letter digits of the alphabet in both lowercase
+// and uppercase. The decoding functions still produce the case of the alphabet.
+func WithAnyCase() Option {
+	return func(cfg *codecConfig) {
+		cfg.anyCase = true
+	}
+}
+
+// NewCodec creates a Codec with the given options. Without options the result behaves exactly like
+// the zero value Codec. ErrOption is returned if an option has an invalid value.
+func NewCodec(options ...Option) (*Codec, error) {
+	cfg := codecConfig{
+		decimalPoint: decimalPoint,
+		terminator:   negativeNumberTerminator,
+		separator:    inTextSeparator,
+		alphabet:     string(digits36[:]),
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	if cfg.terminator <= digitZ {
+		return nil, ErrOption
+	}
+	if cfg.separator == 0 || cfg.separator >= digit0 {
+		return nil, ErrOption
+	}
+
+	c := &Codec{terminator: cfg.terminator, separator: cfg.separator}
+	if cfg.upperCase {
+		cfg.alphabet = strings.ToUpper(cfg.alphabet)
+	}
+	if cfg.decimalPoint != decimalPoint || cfg.alphabet != string(digits36[:]) || cfg.anyCase {
+		f, ok := newNumberFormat(cfg.alphabet, cfg.decimalPoint, cfg.anyCase)
+		if !ok {
+			return nil, ErrOption
+		}
+		c.number = f
+	}
+	return c, nil
+}
+
+// numberFormat translates numbers between a custom format and the standard format of lowercase digits
+// and '.' as the decimal point. A zero in the tables marks a byte without a counterpart.
+type numberFormat struct {
+	toStandard   [256]byte
+	fromStandard [256]byte
+	// base is the length of the alphabet
+	base int
+}
+
+func newNumberFormat(alphabet string, point byte, anyCase bool) (f *numberFormat, ok bool) {
+	if len(alphabet) < minBase || len(alphabet) > maxBase {
+		return nil, false
+	}
+
+	f = &numberFormat{base: len(alphabet)}
+	f.toStandard[minusByte] = minusByte
+	f.toStandard[plusByte] = plusByte
+	f.fromStandard[minusByte] = minusByte
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i] == 0 || f.toStandard[alphabet[i]] != 0 {
+			return nil, false
+		}
+		f.toStandard[alphabet[i]] = digits36[i]
+		f.fromStandard[digits36[i]] = alphabet[i]
+	}
+	if point == 0 || f.toStandard[point] != 0 {
+		return nil, false
+	}
+	f.toStandard[point] = decimalPoint
+	f.fromStandard[decimalPoint] = point
+
+	if anyCase {
+		for i := 0; i < len(alphabet); i++ {
+			if other := otherCase(alphabet[i]); f.toStandard[other] == 0 {
+				f.toStandard[other] = digits36[i]
+			}
+		}
+	}
+	return f, true
+}
+
+func otherCase(b byte) byte {
+	switch {
+	case b >= digitA && b <= digitZ:
+		return b - digitA + digitUpperA
+	case isUpperDigit(b):
+		return b - digitUpperA + digitA
+	}
+	return b
+}
+
+// standardInput returns the input translated into the standard number format. The translation
+// is stored in the scratch buffer, bytes without a counterpart are turned into zeros.
+func (c *Codec) standardInput(input string) string {
+	if c.number == nil {
+		return input
+	}
+	c.scratch = c.emptyScratch()
+	for i := 0; i < len(input); i++ {
+		c.scratch = append(c.scratch, c.number.toStandard[input[i]])
+	}
+	return bytesToString(c.scratch)
+}
+
+// restoreSyntaxError makes the error report the byte of the original input instead of its translation.
+func (c *Codec) restoreSyntaxError(err error, input string) error {
+	if c.number == nil {
+		return err
+	}
+	if syntaxErr, ok := err.(*SyntaxError); ok && syntaxErr.Offset < len(input) {
+		syntaxErr.Byte = input[syntaxErr.Offset]
+	}
+	return err
+}
+
+// formatNumber translates the standard format number in buf[start:] into the number format of the codec.
+func (c *Codec) formatNumber(start int) {
+	if c.number == nil {
+		return
+	}
+	for i := start; i < len(c.buf); i++ {
+		c.buf[i] = c.number.fromStandard[c.buf[i]]
+	}
+}
+
+// zeroNumber returns zero in the number format of the codec.
+func (c *Codec) zeroNumber() string {
+	if c.number == nil {
+		return zeroInput
+	}
+	return string(c.number.fromStandard[digit0])
+}
+
+// highestBase returns the highest base of the numbers the codec accepts and produces.
+func (c *Codec) highestBase() int {
+	if c.number == nil {
+		return maxBase
+	}
+	return c.number.base
+}
+
+func (c *Codec) terminatorByte() byte {
+	if c.terminator == 0 {
+		return negativeNumberTerminator
+	}
+	return c.terminator
+}
+
+func (c *Codec) separatorByte() byte {
+	if c.separator == 0 {
+		return inTextSeparator
+	}
+	return c.separator
+}
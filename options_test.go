@@ -0,0 +1,304 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewCodec_Default(t *testing.T) {
+	configured, err := NewCodec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var zero Codec
+
+	inputs := []string{"", "0", "1", "-1", "54321.12345", "-0.0012", "cowboy.hat", "FF", "1,5", "1.2.3"}
+	for _, input := range inputs {
+		expected, expectedOk := zero.EncodeToken(input)
+		out, ok := configured.EncodeToken(input)
+		if out != expected || ok != expectedOk {
+			t.Errorf("EncodeToken(%q) = %q, %v, expected %q, %v", input, out, ok, expected, expectedOk)
+		}
+
+		decodedExpected, decodedExpectedOk := zero.DecodeToken(expected)
+		decoded, ok := configured.DecodeToken(expected)
+		if decoded != decodedExpected || ok != decodedExpectedOk {
+			t.Errorf("DecodeToken(%q) = %q, %v, expected %q, %v", expected, decoded, ok, decodedExpected, decodedExpectedOk)
+		}
+	}
+
+	text := "Item 20, -3 pcs"
+	expected, _ := zero.EncodeMixedText(text)
+	if out, _ := configured.EncodeMixedText(text); out != expected {
+		t.Errorf("EncodeMixedText(%q) = %q, expected %q", text, out, expected)
+	}
+}
+
+func TestNewCodec_Options(t *testing.T) {
+	crockford := "0123456789abcdefghjkmnpqrstvwxyz"
+
+	testCases := []struct {
+		name    string
+		options []Option
+		input   string
+		encoded string
+		decoded string
+	}{
+		{name: "decimal comma", options: []Option{WithDecimalPoint(',')}, input: "-3,14", encoded: "3ywyv~", decoded: "-3,14"},
+		{name: "decimal comma small", options: []Option{WithDecimalPoint(',')}, input: "0,000125", encoded: "6w125", decoded: "0,000125"},
+		{name: "terminator", options: []Option{WithTerminator('}')}, input: "-3.14", encoded: "3ywyv}", decoded: "-3.14"},
+		{name: "alphabet", options: []Option{WithAlphabet(crockford)}, input: "zz.y", encoded: "72vvu", decoded: "zz.y"},
+		{name: "binary alphabet", options: []Option{WithAlphabet("ab")}, input: "-bab.b", encoded: "3wyzyy~", decoded: "-bab.b"},
+		{name: "upper case", options: []Option{WithUpperCase()}, input: "FF.8", encoded: "72ff8", decoded: "FF.8"},
+		{name: "any case", options: []Option{WithAnyCase()}, input: "Ff.8", encoded: "72ff8", decoded: "ff.8"},
+		{
+			name:    "upper case alphabet",
+			options: []Option{WithAlphabet(crockford), WithUpperCase(), WithDecimalPoint(',')},
+			input:   "ZZ,Y",
+			encoded: "72vvu",
+			decoded: "ZZ,Y",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewCodec(tc.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := c.EncodeTokenErr(tc.input)
+			if err != nil || encoded != tc.encoded {
+				t.Fatalf("EncodeTokenErr(%q) = %q, %v, expected %q", tc.input, encoded, err, tc.encoded)
+			}
+			decoded, err := c.DecodeTokenErr(encoded)
+			if err != nil || decoded != tc.decoded {
+				t.Fatalf("DecodeTokenErr(%q) = %q, %v, expected %q", encoded, decoded, err, tc.decoded)
+			}
+
+			n, err := c.EncodedLen([]byte(tc.input))
+			if err != nil || n != len(tc.encoded) {
+				t.Errorf("EncodedLen(%q) = %d, %v, expected %d", tc.input, n, err, len(tc.encoded))
+			}
+			appended, err := c.AppendDecoded([]byte("x"), []byte(encoded))
+			if err != nil || string(appended) != "x"+tc.decoded {
+				t.Errorf("AppendDecoded(%q) = %q, %v, expected %q", encoded, appended, err, "x"+tc.decoded)
+			}
+		})
+	}
+}
+
+func TestNewCodec_Options_Failure(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []Option
+		input   string
+		offset  int
+		b       byte
+	}{
+		{name: "dot with decimal comma", options: []Option{WithDecimalPoint(',')}, input: "3.14", offset: 1, b: '.'},
+		{name: "digit outside of alphabet", options: []Option{WithAlphabet("ab")}, input: "ab0", offset: 2, b: '0'},
+		{name: "lower case with upper case", options: []Option{WithUpperCase()}, input: "Ff", offset: 1, b: 'f'},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewCodec(tc.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = c.EncodeTokenErr(tc.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("EncodeTokenErr(%q) error = %v, expected a *SyntaxError", tc.input, err)
+			}
+			if syntaxErr.Offset != tc.offset || syntaxErr.Byte != tc.b {
+				t.Errorf("EncodeTokenErr(%q) error = %v, expected %q at offset %d", tc.input, err, tc.b, tc.offset)
+			}
+		})
+	}
+
+	c, _ := NewCodec(WithTerminator('}'))
+	if _, ok := c.DecodeToken("3ywyv~"); ok {
+		t.Error("DecodeToken accepted a token with the default terminator")
+	}
+}
+
+func TestNewCodec_Options_Rebase(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []Option
+		input   string
+		base    int
+		toBase  int
+		rebased string
+	}{
+		{name: "decimal comma", options: []Option{WithDecimalPoint(',')}, input: "-2,5", base: 10, toBase: 2, rebased: "-10,1"},
+		{name: "upper case", options: []Option{WithUpperCase()}, input: "FF.8", base: 16, toBase: 36, rebased: "73.I"},
+		{
+			name:    "upper case with decimal comma",
+			options: []Option{WithUpperCase(), WithDecimalPoint(',')},
+			input:   "-A,C",
+			base:    16,
+			toBase:  2,
+			rebased: "-1010,11",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewCodec(tc.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			compact, err := c.EncodeTokenCompact(tc.input, tc.base)
+			if err != nil {
+				t.Fatalf("EncodeTokenCompact(%q) failed: %v", tc.input, err)
+			}
+			decoded, err := c.DecodeTokenCompact(compact, tc.base)
+			if err != nil || decoded != tc.input {
+				t.Errorf("DecodeTokenCompact(%q) = %q, %v, expected %q", compact, decoded, err, tc.input)
+			}
+
+			token, err := c.EncodeTokenBase(tc.input, tc.base)
+			if err != nil {
+				t.Fatalf("EncodeTokenBase(%q) failed: %v", tc.input, err)
+			}
+			rebased, err := c.DecodeTokenToBase(token, tc.base, tc.toBase, 10)
+			if err != nil || rebased != tc.rebased {
+				t.Errorf("DecodeTokenToBase(%q) = %q, %v, expected %q", token, rebased, err, tc.rebased)
+			}
+		})
+	}
+
+	c, _ := NewCodec(WithDecimalPoint(','))
+	_, err := c.EncodeTokenCompact("3.5", 10)
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 1 || syntaxErr.Byte != '.' {
+		t.Errorf("EncodeTokenCompact(%q) error = %v, expected '.' at offset 1", "3.5", err)
+	}
+}
+
+func TestNewCodec_Options_Alphabet(t *testing.T) {
+	letters, err := NewCodec(WithAlphabet("abcdefghij"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, ok := letters.EncodeToken("a")
+	if !ok || token != "5" {
+		t.Fatalf("EncodeToken(%q) = %q, %v, expected %q", "a", token, ok, "5")
+	}
+	if decoded, err := letters.DecodeTokenErr(token); err != nil || decoded != "a" {
+		t.Errorf("DecodeTokenErr(%q) = %q, %v, expected %q", token, decoded, err, "a")
+	}
+	if rebased, err := letters.DecodeTokenToBase("6z0", 10, 2, 10); err != nil || rebased != "a" {
+		t.Errorf("DecodeTokenToBase of a zero digit token = %q, %v, expected %q", rebased, err, "a")
+	}
+	if decoded, err := letters.DecodeTokenCompact(token, 10); err != nil || decoded != "a" {
+		t.Errorf("DecodeTokenCompact(%q) = %q, %v, expected %q", token, decoded, err, "a")
+	}
+
+	decimal, err := NewCodec(WithAlphabet("0123456789"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = decimal.DecodeTokenErr("72ff")
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Reason != ReasonDigitOutOfBase || syntaxErr.Offset != 2 {
+		t.Errorf("DecodeTokenErr(%q) error = %v, expected %v at offset 2", "72ff", err, ReasonDigitOutOfBase)
+	}
+	if _, err := decimal.DecodedLen([]byte("72ff")); !errors.Is(err, ErrSyntax) {
+		t.Errorf("DecodedLen(%q) error = %v, expected %v", "72ff", err, ErrSyntax)
+	}
+	if _, err := decimal.DecodeTokenToBase("7212", 10, 16, 10); err != ErrBase {
+		t.Errorf("DecodeTokenToBase to base 16 error = %v, expected %v", err, ErrBase)
+	}
+	if _, err := decimal.DecodeTokenCompact("72ff", 16); err != ErrBase {
+		t.Errorf("DecodeTokenCompact to base 16 error = %v, expected %v", err, ErrBase)
+	}
+	if _, err := decimal.DecodeTokenBase("72ff", 16); err != ErrBase {
+		t.Errorf("DecodeTokenBase of base 16 error = %v, expected %v", err, ErrBase)
+	}
+
+	// the digits of the tokens of other bases are not written with the alphabet
+	if rebased, err := decimal.DecodeTokenToBase("72ff", 16, 10, 10); err != nil || rebased != "255" {
+		t.Errorf("DecodeTokenToBase from base 16 = %q, %v, expected %q", rebased, err, "255")
+	}
+	if decoded, err := decimal.DecodeInt64("72ff"); !errors.Is(err, ErrSyntax) || decoded != 0 {
+		t.Errorf("DecodeInt64(%q) = %v, %v, expected %v", "72ff", decoded, err, ErrSyntax)
+	}
+	if decoded, err := decimal.DecodeInt64("7212"); err != nil || decoded != 12 {
+		t.Errorf("DecodeInt64(%q) = %v, %v, expected 12", "7212", decoded, err)
+	}
+}
+
+func TestNewCodec_InvalidOptions(t *testing.T) {
+	testCases := []struct {
+		name   string
+		option Option
+	}{
+		{name: "terminator among digits", option: WithTerminator('z')},
+		{name: "separator above digits", option: WithSeparator('0')},
+		{name: "zero separator", option: WithSeparator(0)},
+		{name: "decimal point among digits", option: WithDecimalPoint('a')},
+		{name: "decimal point is a sign", option: WithDecimalPoint('-')},
+		{name: "short alphabet", option: WithAlphabet("0")},
+		{name: "long alphabet", option: WithAlphabet("0123456789abcdefghijklmnopqrstuvwxyzA")},
+		{name: "repeated digit", option: WithAlphabet("001")},
+		{name: "sign in alphabet", option: WithAlphabet("+01")},
+		{name: "decimal point in alphabet", option: WithAlphabet("0.")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewCodec(tc.option); err != ErrOption {
+				t.Errorf("NewCodec error = %v, expected %v", err, ErrOption)
+			}
+		})
+	}
+}
+
+func TestNewCodec_Sortedness(t *testing.T) {
+	c, err := NewCodec(WithTerminator('}'), WithDecimalPoint(','))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	step := 0.001
+	prev := ""
+	for i := -2000.0; i <= 2000; i++ {
+		token, ok := c.EncodeToken(strings.Replace(fmt.Sprintf("%.3f", i*step), ".", ",", 1))
+		if !ok {
+			t.Fatal("Encoding failed for", i)
+		}
+		if prev >= token {
+			t.Fatal("at", i*step, " ", prev, "is not smaller than", token)
+		}
+		prev = token
+	}
+}
+
+func ExampleNewCodec() {
+	c, err := NewCodec(WithDecimalPoint(','), WithSeparator('/'))
+	if err != nil {
+		panic(err)
+	}
+
+	out, ok := c.EncodeToken("-3,14")
+	fmt.Printf("%q, %v\n", out, ok)
+
+	out, ok = c.DecodeToken(out)
+	fmt.Printf("%q, %v\n", out, ok)
+
+	out, ok = c.EncodeMixedText("Item20")
+	fmt.Printf("%q, %v\n", out, ok)
+
+	// Output:
+	// "3ywyv~", true
+	// "-3,14", true
+	// "Item/722", true
+}
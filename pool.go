@@ -0,0 +1,48 @@
+package conust
+
+import "sync"
+
+// maxPooledBufferSize limits the size of the buffers kept alive by the pool, so that a single
+// large input does not keep a lot of memory allocated.
+const maxPooledBufferSize = 64 * 1024
+
+var codecPool = sync.Pool{
+	New: func() interface{} {
+		return new(Codec)
+	},
+}
+
+func getCodec() *Codec {
+	return codecPool.Get().(*Codec)
+}
+
+func putCodec(c *Codec) {
+	if cap(c.buf) > maxPooledBufferSize || cap(c.scratch) > maxPooledBufferSize {
+		return
+	}
+	codecPool.Put(c)
+}
+
+// EncodeToken works like Codec.EncodeToken. It is safe for concurrent use.
+func EncodeToken(input string) (out string, ok bool) {
+	c := getCodec()
+	out, ok = c.EncodeToken(input)
+	putCodec(c)
+	return
+}
+
+// DecodeToken works like Codec.DecodeToken. It is safe for concurrent use.
+func DecodeToken(input string) (out string, ok bool) {
+	c := getCodec()
+	out, ok = c.DecodeToken(input)
+	putCodec(c)
+	return
+}
+
+// EncodeMixedText works like Codec.EncodeMixedText. It is safe for concurrent use.
+func EncodeMixedText(input string) (out string, ok bool) {
+	c := getCodec()
+	out, ok = c.EncodeMixedText(input)
+	putCodec(c)
+	return
+}
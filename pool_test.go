@@ -0,0 +1,154 @@
+package conust
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestPackageFunctions_Concurrent(t *testing.T) {
+	const workers = 8
+	const iterations = 1000
+
+	var wg sync.WaitGroup
+	errs := make(chan string, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				input := strconv.Itoa((w*iterations+i)*37 - 150000)
+				encoded, ok := EncodeToken(input)
+				if !ok {
+					errs <- fmt.Sprintf("EncodeToken(%q) failed", input)
+					return
+				}
+				decoded, ok := DecodeToken(encoded)
+				if !ok || decoded != input {
+					errs <- fmt.Sprintf("DecodeToken(%q) = %q, %v, expected %q", encoded, decoded, ok, input)
+					return
+				}
+				text := "item " + input + " x"
+				mixed, ok := EncodeMixedText(text)
+				if !ok {
+					errs <- fmt.Sprintf("EncodeMixedText(%q) failed", text)
+					return
+				}
+				if expected := "item " + encoded + " x"; input[0] != '-' && mixed != expected {
+					errs <- fmt.Sprintf("EncodeMixedText(%q) = %q, expected %q", text, mixed, expected)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestCodec_Copy(t *testing.T) {
+	original, err := NewCodec(WithDecimalPoint(','))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fill the buffers of the original, so that the copies inherit them
+	if _, ok := original.EncodeToken("123,456"); !ok {
+		t.Fatal("encoding failed")
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		c := *original
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				encoded, ok := c.EncodeToken("-22,75")
+				if !ok || encoded != "3xxxsu~" {
+					t.Errorf("unexpected token: %q, %v", encoded, ok)
+					return
+				}
+				decoded, ok := c.DecodeToken(encoded)
+				if !ok || decoded != "-22,75" {
+					t.Errorf("unexpected decoded number: %q, %v", decoded, ok)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 1000; i++ {
+		if encoded, _ := original.EncodeToken("98765"); encoded != "7598765" {
+			t.Fatalf("unexpected token of the original: %q", encoded)
+		}
+	}
+	wg.Wait()
+}
+
+func TestCodec_Append_OwnBuffers(t *testing.T) {
+	original, err := NewCodec(WithDecimalPoint(','))
+	if err != nil {
+		t.Fatal(err)
+	}
+	copied := *original
+
+	for name, c := range map[string]*Codec{"new": original, "copy": &copied} {
+		out, err := c.AppendToken([]byte("prefix:"), []byte("1,5"))
+		if err != nil || string(out) != "prefix:7115" {
+			t.Errorf("%s: AppendToken = %q, %v, expected %q", name, out, err, "prefix:7115")
+		}
+		out, err = c.AppendDecoded([]byte("prefix:"), []byte("7115"))
+		if err != nil || string(out) != "prefix:1,5" {
+			t.Errorf("%s: AppendDecoded = %q, %v, expected %q", name, out, err, "prefix:1,5")
+		}
+		out, err = c.AppendMixedText([]byte("prefix:"), []byte("Item20"))
+		if err != nil || string(out) != "prefix:Item 722" {
+			t.Errorf("%s: AppendMixedText = %q, %v, expected %q", name, out, err, "prefix:Item 722")
+		}
+	}
+}
+
+func TestCodec_Copy_Append(t *testing.T) {
+	original, err := NewCodec(WithDecimalPoint(','))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := original.EncodeToken("123,456"); !ok {
+		t.Fatal("encoding failed")
+	}
+
+	c := *original
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var dst []byte
+		for i := 0; i < 1000; i++ {
+			dst, _ = c.AppendToken(dst[:0], []byte("-22,75"))
+			if encoded, _ := c.EncodeToken("-22,75"); encoded != "3xxxsu~" || string(dst) != encoded {
+				t.Errorf("unexpected tokens: %q, %q", dst, encoded)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		if encoded, _ := original.EncodeToken("98765"); encoded != "7598765" {
+			t.Errorf("unexpected token of the original: %q", encoded)
+			break
+		}
+	}
+	<-done
+}
+
+func ExampleEncodeToken() {
+	out, ok := EncodeToken("-3.14")
+	fmt.Printf("%q, %v\n", out, ok)
+
+	out, ok = DecodeToken(out)
+	fmt.Printf("%q, %v\n", out, ok)
+
+	// Output:
+	// "3ywyv~", true
+	// "-3.14", true
+}
@@ -0,0 +1,174 @@
+package conust
+
+import "math/big"
+
+// Rational tokens store the continued fraction [a0; a1, a2, ... an] of the absolute value of the number.
+// A number with a larger a0 is larger, but a larger a1 makes it smaller, and the direction alternates
+// with each further term. So the terms at odd positions are stored with inverted digits.
+// A finite continued fraction behaves as if it was followed by an infinitely large term, which is
+// represented by the terminator: the high terminator in place of a normal term, and the low terminator in
+// place of an inverted term. For negative numbers the direction of every term and the terminator is flipped.
+//
+// Each term is stored as its digit count (in the same chained format as the magnitude of number tokens)
+// followed by all of its base 36 digits, so a term with more digits sorts after the ones with less.
+
+const ratTerminatorLow byte = '!'
+const ratTerminatorHigh byte = '~'
+
+const ratTermBase = 36
+
+// EncodeRat turns the input rational number into a token that holds its exact value.
+// The string order of the tokens is the same as the order of the numbers, so unlike the tokens of
+// EncodeToken, values like 1/3 can be stored and compared without losing precision.
+// Rational tokens can only be compared with other rational tokens.
+// Encoding fails for a nil input.
+func (c *Codec) EncodeRat(input *big.Rat) (out string, ok bool) {
+	if input == nil {
+		return "", false
+	}
+	if input.Sign() == 0 {
+		return zeroOutput, true
+	}
+
+	positive := input.Sign() > 0
+	num := new(big.Int).Abs(input.Num())
+	den := new(big.Int).Set(input.Denom())
+	term := new(big.Int)
+	magnitudePositive := num.Cmp(den) >= 0
+
+	c.buf = c.emptyBuf()
+	c.buf = append(c.buf, c.encodeSign(positive, magnitudePositive))
+
+	// normal and inverted is swapped for negative numbers
+	reverseDigits := !positive
+	for i := 0; ; i++ {
+		term.QuoRem(num, den, num)
+		if i > 0 || magnitudePositive {
+			c.writeRatTerm(reverseDigits, term)
+		}
+		reverseDigits = !reverseDigits
+		if num.Sign() == 0 {
+			break
+		}
+		num, den = den, num
+	}
+
+	if reverseDigits {
+		c.buf = append(c.buf, ratTerminatorLow)
+	} else {
+		c.buf = append(c.buf, ratTerminatorHigh)
+	}
+	return string(c.buf), true
+}
+
+// DecodeRat turns a token created by EncodeRat back into the rational number.
+func (c *Codec) DecodeRat(input string) (out *big.Rat, err error) {
+	if input == zeroOutput {
+		return new(big.Rat), nil
+	}
+	if len(input) < 2 {
+		return nil, ErrSyntax
+	}
+
+	positive, magnitudePositive, ok := c.decodeSigns(input)
+	if !ok {
+		return nil, ErrSyntax
+	}
+
+	// the value is built from the convergents h/k of the continued fraction
+	h, hPrev := big.NewInt(1), new(big.Int)
+	k, kPrev := new(big.Int), big.NewInt(1)
+	term := new(big.Int)
+	temp := new(big.Int)
+
+	reverseDigits := !positive
+	pos := 1
+	termCount := 0
+	storedTermCount := 0
+	if !magnitudePositive {
+		// a0 is zero, which is not stored
+		h, hPrev = hPrev, h
+		k, kPrev = kPrev, k
+		reverseDigits = !reverseDigits
+		termCount++
+	}
+
+	for {
+		if pos >= len(input) {
+			return nil, ErrSyntax
+		}
+		if input[pos] == ratTerminatorLow || input[pos] == ratTerminatorHigh {
+			break
+		}
+
+		pos, ok = c.readRatTerm(input, pos, reverseDigits, term)
+		if !ok {
+			return nil, ErrSyntax
+		}
+
+		temp.Mul(term, h)
+		hPrev.Add(hPrev, temp)
+		h, hPrev = hPrev, h
+		temp.Mul(term, k)
+		kPrev.Add(kPrev, temp)
+		k, kPrev = kPrev, k
+
+		reverseDigits = !reverseDigits
+		termCount++
+		storedTermCount++
+	}
+
+	expectedTerminator := ratTerminatorHigh
+	if reverseDigits {
+		expectedTerminator = ratTerminatorLow
+	}
+	if input[pos] != expectedTerminator || pos != len(input)-1 {
+		return nil, ErrSyntax
+	}
+	// the last term of the canonical form is at least 2, except when it is a0
+	if storedTermCount == 0 || (termCount > 1 && term.Cmp(big.NewInt(1)) <= 0) {
+		return nil, ErrSyntax
+	}
+
+	if !positive {
+		h.Neg(h)
+	}
+	return new(big.Rat).SetFrac(h, k), nil
+}
+
+func (c *Codec) writeRatTerm(reverseDigits bool, term *big.Int) {
+	c.scratch = term.Append(c.emptyScratch(), ratTermBase)
+	c.writeMagnitude(!reverseDigits, true, len(c.scratch))
+	c.writeDigitBytes(!reverseDigits, c.scratch)
+}
+
+// readRatTerm reads the term starting at pos into term, returning the position after it.
+func (c *Codec) readRatTerm(input string, pos int, reverseDigits bool, term *big.Int) (next int, ok bool) {
+	// the digit count is stored the same way as the magnitude of number tokens
+	digitCount, start, ok := c.decodeMagnitudeAt(input, pos, reverseDigits)
+	if !ok {
+		return 0, false
+	}
+	next = start + digitCount
+	if digitCount == 0 || next > len(input) {
+		return 0, false
+	}
+
+	c.scratch = c.emptyScratch()
+	for i := start; i < next; i++ {
+		digit := input[i]
+		if !isDigit(digit) {
+			return 0, false
+		}
+		if reverseDigits {
+			digit = reverseDigit(digit)
+		}
+		c.scratch = append(c.scratch, digit)
+	}
+	if c.scratch[0] == digit0 {
+		return 0, false
+	}
+
+	_, ok = term.SetString(string(c.scratch), ratTermBase)
+	return next, ok
+}
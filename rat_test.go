@@ -0,0 +1,142 @@
+package conust
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestCodec_Rat(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		input   string
+		encoded string
+	}{
+		{name: "zero", input: "0", encoded: "5"},
+		{name: "one", input: "1", encoded: "711!"},
+		{name: "negative one", input: "-1", encoded: "3yy~"},
+		{name: "integer", input: "1200", encoded: "72xc!"},
+		{name: "third", input: "1/3", encoded: "6yw~"},
+		{name: "negative third", input: "-1/3", encoded: "413!"},
+		{name: "two thirds", input: "2/3", encoded: "6yy12!"},
+		{name: "decimal approximation of third", input: "3333333333/10000000000", encoded: "6yw71j4kwgl!"},
+		{name: "improper fraction", input: "22/7", encoded: "713ys~"},
+		{name: "negative improper fraction", input: "-355/113", encoded: "3yw17yj~"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			input, _ := new(big.Rat).SetString(i.input)
+			encoded, ok := codec.EncodeRat(input)
+
+			if !ok {
+				t.Fatalf("Encoding failed for: %v\n", i.input)
+			}
+
+			if encoded != i.encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			decoded, err := codec.DecodeRat(encoded)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if decoded.Cmp(input) != 0 {
+				t.Fatalf("Decoding expected: %v, got %v\n", input, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeRat_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "sign only", input: "7"},
+		{name: "bad prefix", input: "211!"},
+		{name: "no terms", input: "7!"},
+		{name: "no stored terms", input: "6~"},
+		{name: "no terminator", input: "711"},
+		{name: "wrong terminator", input: "711~"},
+		{name: "data after terminator", input: "711!1"},
+		{name: "term too short", input: "721!"},
+		{name: "leading zero in term", input: "7201!"},
+		{name: "non digit in term", input: "72X1!"},
+		{name: "non canonical last term", input: "711yy!"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeRat(i.input)
+
+			if err != ErrSyntax || decoded != nil {
+				t.Fatalf("Decoding should have failed for: %v\n", i.input)
+			}
+		})
+	}
+}
+
+func TestRatSortedness(t *testing.T) {
+	values := make([]*big.Rat, 0)
+	for num := int64(-60); num <= 60; num++ {
+		for den := int64(1); den <= 40; den++ {
+			values = append(values, big.NewRat(num, den))
+		}
+	}
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 2000; i++ {
+		num := new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), 100))
+		den := new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), 90))
+		if r.Intn(2) == 0 {
+			num.Neg(num)
+		}
+		values = append(values, new(big.Rat).SetFrac(num, den.Add(den, big.NewInt(1))))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+
+	c := new(Codec)
+	prev := LessThanAny
+	for i, v := range values {
+		encoded, ok := c.EncodeRat(v)
+		if !ok {
+			t.Fatal("Encoding failed for", v)
+		}
+		if i > 0 && values[i-1].Cmp(v) == 0 {
+			if prev != encoded {
+				t.Fatal("at", v, " ", prev, "is not equal to", encoded)
+			}
+		} else if prev >= encoded {
+			t.Fatal("at", v, " ", prev, "is not smaller than", encoded)
+		}
+		decoded, err := c.DecodeRat(encoded)
+		if err != nil || decoded.Cmp(v) != 0 {
+			t.Fatal("Decoding failed for", v, encoded, decoded, err)
+		}
+		prev = encoded
+	}
+	if prev >= GreaterThanAny {
+		t.Fatal(prev, "is not smaller than", GreaterThanAny)
+	}
+}
+
+func ExampleCodec_EncodeRat() {
+	c := new(Codec)
+
+	out, ok := c.EncodeRat(big.NewRat(1, 3))
+	fmt.Printf("%q, %v\n", out, ok)
+
+	out, ok = c.EncodeRat(big.NewRat(3333, 10000))
+	fmt.Printf("%q, %v\n", out, ok)
+
+	// Output:
+	// "6yw~", true
+	// "6yw32kl!", true
+}
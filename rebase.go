@@ -0,0 +1,229 @@
+package conust
+
+import "math/big"
+
+// compactBase is the base of the tokens created by EncodeTokenCompact.
+const compactBase = maxBase
+
+// DecodeTokenToBase turns a Conust token holding a number of base fromBase back into its normal representation
+// in base toBase. The integer part is converted exactly, while the fractional part is cut after at most
+// maxFractionDigits digits, since a finite fraction in one base might not have a finite representation in another.
+// Both bases must be between 2 and 36, and toBase can not be higher than the length of the alphabet of the codec.
+// The output has the same form as the output of DecodeToken.
+func (c *Codec) DecodeTokenToBase(input string, fromBase int, toBase int, maxFractionDigits int) (out string, err error) {
+	if fromBase < minBase || fromBase > maxBase || toBase < minBase || toBase > c.highestBase() {
+		return "", ErrBase
+	}
+	if input == "" {
+		return "", nil
+	}
+	if input == zeroOutput {
+		return c.zeroNumber(), nil
+	}
+
+	// the digits of the input are not written with the alphabet, only those of the output
+	t, err := c.parseTokenDigits(input, fromBase)
+	if err != nil {
+		return "", err
+	}
+
+	// the value is significand * fromBase^exponent
+	significand, exponent := c.tokenSignificand(input, t, fromBase)
+	if significand.Sign() == 0 {
+		// non-canonical tokens might hold only zero digits
+		return c.zeroNumber(), nil
+	}
+
+	c.scratch = c.emptyScratch()
+	if !t.positive {
+		c.scratch = append(c.scratch, minusByte)
+	}
+
+	if exponent >= 0 {
+		scale := new(big.Int).Exp(big.NewInt(int64(fromBase)), big.NewInt(int64(exponent)), nil)
+		c.scratch = significand.Mul(significand, scale).Append(c.scratch, toBase)
+		return c.formatScratch(), nil
+	}
+
+	den := new(big.Int).Exp(big.NewInt(int64(fromBase)), big.NewInt(int64(-exponent)), nil)
+	intPart, rem := new(big.Int).QuoRem(significand, den, new(big.Int))
+	c.scratch = intPart.Append(c.scratch, toBase)
+	c.scratch = append(c.scratch, decimalPoint)
+	fractionStart := len(c.scratch)
+
+	toBaseInt := big.NewInt(int64(toBase))
+	digit := new(big.Int)
+	for i := 0; i < maxFractionDigits && rem.Sign() != 0; i++ {
+		rem.Mul(rem, toBaseInt)
+		digit.QuoRem(rem, den, rem)
+		c.scratch = append(c.scratch, intToDigit(int(digit.Int64())))
+	}
+	for len(c.scratch) > fractionStart && c.scratch[len(c.scratch)-1] == digit0 {
+		c.scratch = c.scratch[:len(c.scratch)-1]
+	}
+	if len(c.scratch) == fractionStart {
+		// nothing remained of the fraction
+		c.scratch = c.scratch[:fractionStart-1]
+		if intPart.Sign() == 0 {
+			return c.zeroNumber(), nil
+		}
+	}
+	return c.formatScratch(), nil
+}
+
+// formatScratch returns the standard format number held by the scratch buffer in the number format of the codec.
+func (c *Codec) formatScratch() string {
+	if c.number == nil {
+		return string(c.scratch)
+	}
+	c.buf = append(c.emptyBuf(), c.scratch...)
+	c.formatNumber(0)
+	return string(c.buf)
+}
+
+// EncodeTokenCompact turns the input number of the given base into the token of the same value expressed
+// in base 36. Since the order of the tokens is only correct among tokens of the same base, this allows
+// numbers of different bases to be stored together, and it also shortens the tokens of decimal numbers.
+//
+// Only integers and fractions whose denominator divides a power of 36 can be converted, since other fractions
+// have no finite representation in base 36. For decimal input this means that the fractional part must be
+// a multiple of 1/2^n, like 0.5 or 0.25, so most decimal fractions like 0.1 or 19.99 can not be encoded,
+// and ErrPrecision is returned for them.
+func (c *Codec) EncodeTokenCompact(input string, base int) (out string, err error) {
+	if base < minBase || base > c.highestBase() {
+		return "", ErrBase
+	}
+	if input == "" {
+		return "", nil
+	}
+	original := input
+	input = c.standardInput(input)
+	if err := c.validateInput(input, base); err != nil {
+		return "", c.restoreSyntaxError(err, original)
+	}
+
+	positive := c.getPositivity(input)
+	decimalPointPos := c.getDecimalPointPos(input)
+	sStartPos := c.getSignificantStartPos(input)
+	sEndPos := c.getSignificantEndPos(input)
+	if sStartPos == sEndPos {
+		return zeroOutput, nil
+	}
+
+	magnitude, magnitudePositive := c.getMagnitudeParams(len(input), sStartPos, sEndPos, decimalPointPos, 0)
+	integerDigits := magnitude
+	if !magnitudePositive {
+		integerDigits = -magnitude
+	}
+
+	// the input might be held by the scratch buffer, so the digits are collected in the buffer
+	c.buf = c.emptyBuf()
+	for i := sStartPos; i < sEndPos; i++ {
+		if input[i] != decimalPoint {
+			c.buf = append(c.buf, input[i])
+		}
+	}
+	significand, _ := new(big.Int).SetString(string(c.buf), base)
+
+	digits, integerDigits, ok := c.rebase(significand, integerDigits-len(c.buf), base, compactBase)
+	if !ok {
+		return "", ErrPrecision
+	}
+	if integerDigits > 0 {
+		return c.writeToken(positive, true, integerDigits, digits), nil
+	}
+	return c.writeToken(positive, false, -integerDigits, digits), nil
+}
+
+// DecodeTokenCompact turns a token created by EncodeTokenCompact back into its normal representation
+// in the given base. ErrPrecision is returned for fractions that have no finite representation in that base.
+func (c *Codec) DecodeTokenCompact(input string, base int) (out string, err error) {
+	if base < minBase || base > c.highestBase() {
+		return "", ErrBase
+	}
+	if input == "" {
+		return "", nil
+	}
+	if input == zeroOutput {
+		return c.zeroNumber(), nil
+	}
+
+	t, err := c.parseTokenDigits(input, compactBase)
+	if err != nil {
+		return "", err
+	}
+
+	significand, exponent := c.tokenSignificand(input, t, compactBase)
+	if significand.Sign() == 0 {
+		// non-canonical tokens might hold only zero digits
+		return c.zeroNumber(), nil
+	}
+	digits, integerDigits, ok := c.rebase(significand, exponent, compactBase, base)
+	if !ok {
+		return "", ErrPrecision
+	}
+
+	var token string
+	if integerDigits > 0 {
+		token = c.writeToken(t.positive, true, integerDigits, digits)
+	} else {
+		token = c.writeToken(t.positive, false, -integerDigits, digits)
+	}
+	return c.decodeTokenBase(token, base)
+}
+
+// rebase converts the non-zero value significand * fromBase^exponent into toBase. It returns the significant
+// digits without leading and trailing zeros, and the number of integer digits, which is the negative of the
+// number of leading fractional zeros for values below 1. It reports false if the value has no finite
+// representation in toBase. The significand is modified.
+func (c *Codec) rebase(significand *big.Int, exponent int, fromBase int, toBase int) (digits []byte, integerDigits int, ok bool) {
+	// the value is turned into an integer by multiplying it with toBase^fractionDigits
+	fractionDigits := 0
+	if exponent >= 0 {
+		significand.Mul(significand, new(big.Int).Exp(big.NewInt(int64(fromBase)), big.NewInt(int64(exponent)), nil))
+	} else {
+		den := new(big.Int).Exp(big.NewInt(int64(fromBase)), big.NewInt(int64(-exponent)), nil)
+		g := new(big.Int).GCD(nil, nil, significand, den)
+		significand.Quo(significand, g)
+		den.Quo(den, g)
+
+		toBaseInt := big.NewInt(int64(toBase))
+		factor := new(big.Int)
+		for den.Cmp(big.NewInt(1)) != 0 {
+			g.GCD(nil, nil, den, toBaseInt)
+			if g.Cmp(big.NewInt(1)) == 0 {
+				return nil, 0, false
+			}
+			den.Quo(den, g)
+			significand.Mul(significand, factor.Quo(toBaseInt, g))
+			fractionDigits++
+		}
+	}
+
+	c.scratch = significand.Append(c.emptyScratch(), toBase)
+	integerDigits = len(c.scratch) - fractionDigits
+	for c.scratch[len(c.scratch)-1] == digit0 {
+		c.scratch = c.scratch[:len(c.scratch)-1]
+	}
+	return c.scratch, integerDigits, true
+}
+
+// tokenSignificand returns the significant digits of a non-zero token as an integer, and the exponent
+// that the base has to be raised to, to get the value of the token when multiplied with the integer.
+func (c *Codec) tokenSignificand(input string, t tokenLayout, base int) (significand *big.Int, exponent int) {
+	c.scratch = c.emptyScratch()
+	for i := t.sStartPos; i < t.sEndPos; i++ {
+		digit := input[i]
+		if !t.positive {
+			digit = reverseDigit(digit)
+		}
+		c.scratch = append(c.scratch, digit)
+	}
+	significand, _ = new(big.Int).SetString(string(c.scratch), base)
+
+	integerDigits := t.magnitude
+	if !t.magnitudePositive {
+		integerDigits = -t.magnitude
+	}
+	return significand, integerDigits - (t.sEndPos - t.sStartPos)
+}
@@ -0,0 +1,175 @@
+package conust
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCodec_DecodeTokenToBase(t *testing.T) {
+	codecTests := []struct {
+		name              string
+		input             string
+		fromBase          int
+		toBase            int
+		maxFractionDigits int
+		decoded           string
+	}{
+		{name: "empty", input: "", fromBase: 36, toBase: 10, maxFractionDigits: 5, decoded: ""},
+		{name: "zero", input: "5", fromBase: 36, toBase: 10, maxFractionDigits: 5, decoded: "0"},
+		{name: "same base", input: "755432112345", fromBase: 10, toBase: 10, maxFractionDigits: 10, decoded: "54321.12345"},
+		{name: "integer to hex", input: "75864", fromBase: 10, toBase: 16, maxFractionDigits: 0, decoded: "15180"},
+		{name: "base 36 to decimal", input: "72zz", fromBase: 36, toBase: 10, maxFractionDigits: 0, decoded: "1295"},
+		{name: "trailing zeros", input: "7z412", fromBase: 10, toBase: 36, maxFractionDigits: 0, decoded: "j8o40es6zyngyelxzsc7ln9c"},
+		{name: "negative", input: "3xyx~", fromBase: 10, toBase: 2, maxFractionDigits: 0, decoded: "-1100"},
+		{name: "exact fraction", input: "6z5", fromBase: 10, toBase: 2, maxFractionDigits: 10, decoded: "0.1"},
+		{name: "hex fraction", input: "72ff8", fromBase: 16, toBase: 10, maxFractionDigits: 10, decoded: "255.5"},
+		{name: "cut fraction", input: "6zi", fromBase: 36, toBase: 10, maxFractionDigits: 4, decoded: "0.5"},
+		{name: "repeating fraction", input: "6za", fromBase: 36, toBase: 10, maxFractionDigits: 4, decoded: "0.2777"},
+		{name: "negative repeating fraction", input: "40p~", fromBase: 36, toBase: 10, maxFractionDigits: 4, decoded: "-0.2777"},
+		{name: "fraction cut to nothing", input: "6x1", fromBase: 10, toBase: 10, maxFractionDigits: 2, decoded: "0"},
+		{name: "negative fraction cut to nothing", input: "4yy~", fromBase: 10, toBase: 10, maxFractionDigits: 2, decoded: "0"},
+		{name: "fraction cut to integer", input: "3wyxzu~", fromBase: 10, toBase: 10, maxFractionDigits: 0, decoded: "-120"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeTokenToBase(i.input, i.fromBase, i.toBase, i.maxFractionDigits)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", i.input, err)
+			}
+
+			if i.decoded != decoded {
+				t.Fatalf("Decoding expected: %v, got %v\n", i.decoded, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeTokenToBase_Failure(t *testing.T) {
+	codec := new(Codec)
+
+	if _, err := codec.DecodeTokenToBase("711", 1, 10, 0); err != ErrBase {
+		t.Fatalf("Expected %v, got %v\n", ErrBase, err)
+	}
+	if _, err := codec.DecodeTokenToBase("711", 10, 37, 0); err != ErrBase {
+		t.Fatalf("Expected %v, got %v\n", ErrBase, err)
+	}
+	if _, err := codec.DecodeTokenToBase("7219", 8, 10, 0); err == nil || err.(*SyntaxError).Reason != ReasonDigitOutOfBase {
+		t.Fatalf("Expected digit out of base, got %v\n", err)
+	}
+	if _, err := codec.DecodeTokenToBase("40zx", 10, 10, 0); err == nil || err.(*SyntaxError).Reason != ReasonMissingTerminator {
+		t.Fatalf("Expected missing terminator, got %v\n", err)
+	}
+}
+
+func TestCodec_TokenCompact(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		input   string
+		base    int
+		encoded string
+		decoded string
+	}{
+		{name: "empty", input: "", base: 10, encoded: "", decoded: ""},
+		{name: "zero", input: "-0.00", base: 10, encoded: "5", decoded: "0"},
+		{name: "one", input: "1", base: 10, encoded: "711", decoded: "1"},
+		{name: "decimal id", input: "1234567890123456789", base: 10, encoded: "7c9do1sj396nf9", decoded: "1234567890123456789"},
+		{name: "negative", input: "-1295", base: 10, encoded: "3x00~", decoded: "-1295"},
+		{name: "trailing zeros", input: "1296", base: 10, encoded: "731", decoded: "1296"},
+		{name: "half", input: "0.5", base: 10, encoded: "6zi", decoded: "0.5"},
+		{name: "quarter", input: "-0.25", base: 10, encoded: "40q~", decoded: "-0.25"},
+		{name: "small fraction", input: "0.0000152587890625", base: 10, encoded: "6wpmn29", decoded: "0.0000152587890625"},
+		{name: "mixed", input: "12.75", base: 10, encoded: "71cr", decoded: "12.75"},
+		{name: "binary", input: "-101.1", base: 2, encoded: "3yuh~", decoded: "-101.1"},
+		{name: "hex", input: "ff.8", base: 16, encoded: "7273i", decoded: "ff.8"},
+		{name: "base 36", input: "cowboy.hat", base: 36, encoded: "76cowboyhat", decoded: "cowboy.hat"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded, err := codec.EncodeTokenCompact(i.input, i.base)
+
+			if err != nil {
+				t.Fatalf("Encoding failed for: %v: %v\n", i.input, err)
+			}
+
+			if i.encoded != encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			decoded, err := codec.DecodeTokenCompact(encoded, i.base)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if i.decoded != decoded {
+				t.Fatalf("Decoding expected: %v, got %v\n", i.decoded, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_TokenCompact_Failure(t *testing.T) {
+	codec := new(Codec)
+
+	for _, input := range []string{"0.2", "0.1", "19.99", "-0.05", "3.14", "0.001"} {
+		if _, err := codec.EncodeTokenCompact(input, 10); err != ErrPrecision {
+			t.Fatalf("EncodeTokenCompact(%q): expected %v, got %v\n", input, ErrPrecision, err)
+		}
+	}
+	if _, err := codec.DecodeTokenCompact("6zc", 10); err != ErrPrecision {
+		t.Fatalf("Expected %v, got %v\n", ErrPrecision, err)
+	}
+	if _, err := codec.EncodeTokenCompact("19", 8); err == nil || err.(*SyntaxError).Reason != ReasonDigitOutOfBase {
+		t.Fatalf("Expected digit out of base, got %v\n", err)
+	}
+	if _, err := codec.EncodeTokenCompact("1", 37); err != ErrBase {
+		t.Fatalf("Expected %v, got %v\n", ErrBase, err)
+	}
+	if _, err := codec.DecodeTokenCompact("711", 1); err != ErrBase {
+		t.Fatalf("Expected %v, got %v\n", ErrBase, err)
+	}
+
+	// non-canonical tokens holding only zero digits are accepted by DecodeToken as well
+	for _, input := range []string{"710", "610", "3yz~"} {
+		if decoded, err := codec.DecodeTokenCompact(input, 10); err != nil || decoded != zeroInput {
+			t.Fatalf("DecodeTokenCompact(%q) = %q, %v, expected %q\n", input, decoded, err, zeroInput)
+		}
+		if decoded, err := codec.DecodeTokenToBase(input, 10, 2, 10); err != nil || decoded != zeroInput {
+			t.Fatalf("DecodeTokenToBase(%q) = %q, %v, expected %q\n", input, decoded, err, zeroInput)
+		}
+	}
+}
+
+func TestTokenCompactSortedness(t *testing.T) {
+	c := new(Codec)
+	prev := LessThanAny
+	for i := -5000; i <= 5000; i++ {
+		encoded, err := c.EncodeTokenCompact(fmt.Sprintf("%.2f", float64(i)/4), 10)
+		if err != nil {
+			t.Fatal("Encoding failed for", i, err)
+		}
+		if prev >= encoded {
+			t.Fatal("at", i, " ", prev, "is not smaller than", encoded)
+		}
+		prev = encoded
+	}
+}
+
+func ExampleCodec_DecodeTokenToBase() {
+	c := new(Codec)
+
+	out, err := c.DecodeTokenToBase("72ff8", 16, 10, 5)
+	fmt.Printf("%q, %v\n", out, err)
+
+	out, err = c.DecodeTokenToBase("6za", 36, 10, 5)
+	fmt.Printf("%q, %v\n", out, err)
+
+	// Output:
+	// "255.5", <nil>
+	// "0.27777", <nil>
+}
@@ -0,0 +1,133 @@
+package conust
+
+import (
+	"math"
+	"time"
+)
+
+// nanoDigits is the number of fractional digits of a second needed for nanosecond precision.
+const nanoDigits = 9
+
+const nanosPerSecond = 1000000000
+
+// EncodeTime turns the input time into a token holding the signed number of seconds elapsed since
+// the Unix epoch, with the nanoseconds as its fractional part. The tokens sort chronologically,
+// independently of the location of the time, and the representable range is not limited to the
+// range of UnixNano.
+func (c *Codec) EncodeTime(input time.Time) string {
+	sec := input.Unix()
+	nsec := uint64(input.Nanosecond())
+	if sec >= 0 {
+		return c.encodeFixedPoint(true, uint64(sec), nsec, nanoDigits)
+	}
+	if nsec == 0 {
+		// for math.MinInt64 the negation overflows, but the conversion still yields the right value
+		return c.encodeFixedPoint(false, uint64(-sec), 0, nanoDigits)
+	}
+	// -5 s + 300000000 ns is -4.7 s
+	return c.encodeFixedPoint(false, uint64(-(sec + 1)), nanosPerSecond-nsec, nanoDigits)
+}
+
+// DecodeTime turns a token created by EncodeTime back into a time in UTC.
+// ErrPrecision is returned if the token holds fractions of a nanosecond, and ErrRange
+// if the value is beyond the range of time.Unix.
+func (c *Codec) DecodeTime(input string) (out time.Time, err error) {
+	positive, intPart, frac, err := c.decodeFixedPoint(input, nanoDigits)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if intPart > math.MaxInt64 {
+		return time.Time{}, ErrRange
+	}
+
+	sec := int64(intPart)
+	if !positive {
+		if frac > 0 {
+			sec = -sec - 1
+			frac = nanosPerSecond - frac
+		} else {
+			sec = -sec
+		}
+	}
+	return time.Unix(sec, int64(frac)).UTC(), nil
+}
+
+// EncodeDuration turns the input duration into a token holding the signed number of seconds,
+// with the nanoseconds as its fractional part. So for example 1.5 seconds is encoded as "1.5" would be.
+func (c *Codec) EncodeDuration(input time.Duration) string {
+	positive := input >= 0
+	// for math.MinInt64 the negation overflows, but the conversion still yields the right value
+	abs := uint64(input)
+	if !positive {
+		abs = uint64(-input)
+	}
+	return c.encodeFixedPoint(positive, abs/nanosPerSecond, abs%nanosPerSecond, nanoDigits)
+}
+
+// DecodeDuration turns a token created by EncodeDuration back into a duration.
+// ErrPrecision is returned if the token holds fractions of a nanosecond, and ErrRange
+// if the value does not fit into a time.Duration.
+func (c *Codec) DecodeDuration(input string) (out time.Duration, err error) {
+	positive, intPart, frac, err := c.decodeFixedPoint(input, nanoDigits)
+	if err != nil {
+		return 0, err
+	}
+	if intPart > (math.MaxUint64-frac)/nanosPerSecond {
+		return 0, ErrRange
+	}
+
+	abs := intPart*nanosPerSecond + frac
+	if positive {
+		if abs > math.MaxInt64 {
+			return 0, ErrRange
+		}
+		return time.Duration(abs), nil
+	}
+	if abs > 1<<63 {
+		return 0, ErrRange
+	}
+	return -time.Duration(abs), nil
+}
+
+// EncodeDate turns a calendar date into a token holding the number year * 10000 + month * 100 + day,
+// so the date 2006-01-02 is encoded as 20060102 would be. The tokens sort chronologically for
+// negative years as well. Encoding fails if the date does not exist.
+func (c *Codec) EncodeDate(year int, month time.Month, day int) (out string, ok bool) {
+	if !isValidDate(int64(year), month, day) {
+		return "", false
+	}
+	return c.EncodeInt64(int64(year)*10000 + int64(month)*100 + int64(day)), true
+}
+
+// DecodeDate turns a token created by EncodeDate back into a calendar date.
+// ErrRange is returned if the token does not hold a valid date.
+func (c *Codec) DecodeDate(input string) (year int, month time.Month, day int, err error) {
+	v, err := c.DecodeInt64(input)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	y := v / 10000
+	monthDay := v % 10000
+	if monthDay < 0 {
+		y--
+		monthDay += 10000
+	}
+	if int64(int(y)) != y || !isValidDate(y, time.Month(monthDay/100), int(monthDay%100)) {
+		return 0, 0, 0, ErrRange
+	}
+	return int(y), time.Month(monthDay / 100), int(monthDay % 100), nil
+}
+
+// minDateYear and maxDateYear keep year * 10000 + 1231 within the range of int64.
+const minDateYear = math.MinInt64/10000 + 1
+const maxDateYear = math.MaxInt64/10000 - 1
+
+func isValidDate(year int64, month time.Month, day int) bool {
+	if year < minDateYear || year > maxDateYear || month < time.January || month > time.December || day < 1 {
+		return false
+	}
+	// the calendar repeats itself every 400 years, which keeps time.Date within its range
+	t := time.Date(int(year%400), month, day, 0, 0, 0, 0, time.UTC)
+	return t.Month() == month && t.Day() == day
+}
@@ -0,0 +1,250 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCodec_Time(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		input   time.Time
+		encoded string
+	}{
+		{name: "epoch", input: time.Unix(0, 0), encoded: "5"},
+		{name: "one second", input: time.Unix(1, 0), encoded: "711"},
+		{name: "one nanosecond", input: time.Unix(0, 1), encoded: "6r1"},
+		{name: "minus one nanosecond", input: time.Unix(0, -1), encoded: "48y~"},
+		{name: "fraction", input: time.Unix(86400, 500000000), encoded: "75864005"},
+		{name: "negative fraction", input: time.Unix(-5, 300000000), encoded: "3yvs~"},
+		{name: "zero time", input: time.Time{}, encoded: "3otxywuuqtr~"},
+		{name: "before UnixNano", input: time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC), encoded: "3oyvrwystqt~"},
+		{name: "after UnixNano", input: time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC), encoded: "7b167252256"},
+		{name: "far future", input: time.Date(200000, 1, 1, 0, 0, 0, 123, time.UTC), encoded: "7d6249223180800000000123"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded := codec.EncodeTime(i.input)
+
+			if encoded != i.encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			decoded, err := codec.DecodeTime(encoded)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if !decoded.Equal(i.input) || decoded.Location() != time.UTC {
+				t.Fatalf("Decoding expected: %v, got %v\n", i.input, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_EncodeTime_Location(t *testing.T) {
+	codec := new(Codec)
+	instant := time.Date(2020, 2, 29, 12, 30, 0, 0, time.UTC)
+	zone := time.FixedZone("UTC+5", 5*60*60)
+
+	if codec.EncodeTime(instant) != codec.EncodeTime(instant.In(zone)) {
+		t.Fatal("the same instant in different locations is encoded differently")
+	}
+}
+
+func TestCodec_DecodeTime_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "empty", input: "", err: ErrSyntax},
+		{name: "non decimal digit", input: "721a", err: ErrSyntax},
+		{name: "below nanosecond", input: "6p1", err: ErrPrecision},
+		{name: "too large", input: "7z11", err: ErrRange},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeTime(i.input)
+
+			if !errors.Is(err, i.err) || !decoded.IsZero() {
+				t.Fatalf("Decoding expected error %v, got %v, %v\n", i.err, decoded, err)
+			}
+		})
+	}
+}
+
+func TestTimeSortedness(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	values := make([]time.Time, 0, 10000)
+	for len(values) < cap(values) {
+		sec := r.Int63n(1<<40) - 1<<39
+		values = append(values, time.Unix(sec, r.Int63n(nanosPerSecond)))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Before(values[j]) })
+
+	c := new(Codec)
+	prev := LessThanAny
+	for i, v := range values {
+		encoded := c.EncodeTime(v)
+		if prev >= encoded && !(i > 0 && values[i-1].Equal(v)) {
+			t.Fatal("at", v, " ", prev, "is not smaller than", encoded)
+		}
+		prev = encoded
+	}
+}
+
+func TestCodec_Duration(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		input   time.Duration
+		encoded string
+	}{
+		{name: "zero", input: 0, encoded: "5"},
+		{name: "one second", input: time.Second, encoded: "711"},
+		{name: "one and a half seconds", input: 1500 * time.Millisecond, encoded: "7115"},
+		{name: "minus one and a half seconds", input: -1500 * time.Millisecond, encoded: "3yyu~"},
+		{name: "one nanosecond", input: time.Nanosecond, encoded: "6r1"},
+		{name: "hour", input: time.Hour, encoded: "7436"},
+		{name: "max", input: math.MaxInt64, encoded: "7a9223372036854775807"},
+		{name: "min", input: math.MinInt64, encoded: "3pqxxwwsxzwtruvssurzr~"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded := codec.EncodeDuration(i.input)
+
+			if encoded != i.encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			decoded, err := codec.DecodeDuration(encoded)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if decoded != i.input {
+				t.Fatalf("Decoding expected: %v, got %v\n", i.input, decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeDuration_Failure(t *testing.T) {
+	codecTests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "empty", input: "", err: ErrSyntax},
+		{name: "below nanosecond", input: "6p1", err: ErrPrecision},
+		{name: "above max", input: "7a9223372036854775808", err: ErrRange},
+		{name: "below min", input: "3pqxxwwsxzwtruvssurzq~", err: ErrRange},
+		{name: "beyond uint64", input: "7k1", err: ErrRange},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			decoded, err := codec.DecodeDuration(i.input)
+
+			if !errors.Is(err, i.err) || decoded != 0 {
+				t.Fatalf("Decoding expected error %v, got %v, %v\n", i.err, decoded, err)
+			}
+		})
+	}
+}
+
+func TestCodec_Date(t *testing.T) {
+	codecTests := []struct {
+		name    string
+		year    int
+		month   time.Month
+		day     int
+		encoded string
+	}{
+		{name: "regular", year: 2006, month: time.January, day: 2, encoded: "7820060102"},
+		{name: "leap day", year: 2000, month: time.February, day: 29, encoded: "7820000229"},
+		{name: "year zero", year: 0, month: time.December, day: 31, encoded: "741231"},
+		{name: "first day of year one", year: 1, month: time.January, day: 1, encoded: "7510101"},
+		{name: "negative year", year: -5, month: time.March, day: 1, encoded: "3uvqtqq~"},
+	}
+
+	codec := new(Codec)
+	for _, i := range codecTests {
+		t.Run(i.name, func(t *testing.T) {
+			encoded, ok := codec.EncodeDate(i.year, i.month, i.day)
+
+			if !ok {
+				t.Fatalf("Encoding failed for: %v-%v-%v\n", i.year, i.month, i.day)
+			}
+
+			if encoded != i.encoded {
+				t.Fatalf("Encoding expected: %v, got %v\n", i.encoded, encoded)
+			}
+
+			year, month, day, err := codec.DecodeDate(encoded)
+
+			if err != nil {
+				t.Fatalf("Decoding failed for: %v: %v\n", encoded, err)
+			}
+
+			if year != i.year || month != i.month || day != i.day {
+				t.Fatalf("Decoding expected: %v-%v-%v, got %v-%v-%v\n", i.year, i.month, i.day, year, month, day)
+			}
+		})
+	}
+}
+
+func TestCodec_Date_Failure(t *testing.T) {
+	codec := new(Codec)
+
+	if encoded, ok := codec.EncodeDate(2001, time.February, 29); ok || encoded != "" {
+		t.Fatal("Encoding should have failed for a non existing leap day")
+	}
+	if encoded, ok := codec.EncodeDate(2001, 13, 1); ok || encoded != "" {
+		t.Fatal("Encoding should have failed for an invalid month")
+	}
+
+	for _, input := range []string{"", "7820060132", "7820061301", "7820060102.5", "782006010205"} {
+		if _, _, _, err := codec.DecodeDate(input); err == nil {
+			t.Fatalf("Decoding should have failed for: %v\n", input)
+		}
+	}
+}
+
+func TestDateSortedness(t *testing.T) {
+	c := new(Codec)
+	prev := LessThanAny
+	for d := time.Date(-3, 1, 1, 0, 0, 0, 0, time.UTC); d.Year() < 4; d = d.AddDate(0, 0, 1) {
+		encoded, ok := c.EncodeDate(d.Year(), d.Month(), d.Day())
+		if !ok {
+			t.Fatal("Encoding failed for", d)
+		}
+		if prev >= encoded {
+			t.Fatal("at", d, " ", prev, "is not smaller than", encoded)
+		}
+		prev = encoded
+	}
+}
+
+func ExampleCodec_EncodeTime() {
+	c := new(Codec)
+
+	fmt.Printf("%q\n", c.EncodeTime(time.Date(1970, 1, 2, 0, 0, 0, 500000000, time.UTC)))
+
+	// Output:
+	// "75864005"
+}
@@ -0,0 +1,164 @@
+package conust
+
+import "strings"
+
+// TupleElementKind tells the type of a TupleElement.
+type TupleElementKind int
+
+const (
+	// TupleNumber is an element holding a number, which is stored as a Conust token.
+	TupleNumber TupleElementKind = iota + 1
+	// TupleText is an element holding text.
+	TupleText
+	// TupleBytes is an element holding arbitrary bytes.
+	TupleBytes
+)
+
+// Every element starts with the tag of its kind, so elements of different kinds sort by their kind.
+const (
+	tupleNumberTag byte = 0x04
+	tupleTextTag   byte = 0x05
+	tupleBytesTag  byte = 0x06
+)
+
+// The elements end with a zero byte, which sorts before any content, so that an element sorts before all elements
+// it is the prefix of. Zero bytes in text and bytes elements are escaped with the byte following them.
+const (
+	tupleElementEnd byte = 0x00
+	tupleTextEnd    byte = 0x01
+	tupleEscapedEnd byte = 0xff
+)
+
+// TupleElement is an element of a tuple encoded by EncodeTuple.
+type TupleElement struct {
+	Kind TupleElementKind
+	// Value holds the number of TupleNumber elements and the text of TupleText elements.
+	Value string
+	// Bytes holds the content of TupleBytes elements.
+	Bytes []byte
+}
+
+// NumberElement returns a tuple element holding a number in the format accepted by EncodeToken.
+func NumberElement(number string) TupleElement {
+	return TupleElement{Kind: TupleNumber, Value: number}
+}
+
+// TextElement returns a tuple element holding text.
+func TextElement(text string) TupleElement {
+	return TupleElement{Kind: TupleText, Value: text}
+}
+
+// BytesElement returns a tuple element holding arbitrary bytes.
+func BytesElement(b []byte) TupleElement {
+	return TupleElement{Kind: TupleBytes, Bytes: b}
+}
+
+// EncodeTuple encodes a list of elements into a single key that sorts element by element, like the keys
+// of a composite index: tuples are ordered by their first element, then by their second, and so on,
+// while a tuple sorts before the longer tuples it is the prefix of. Numbers are compared by their value,
+// text and bytes by their bytes. Elements of different kinds at the same position are ordered by their kind.
+// The output is binary, it may hold any byte, including zero.
+// If a number can not be encoded, a *SyntaxError is returned with the offset counted within that number.
+func (c *Codec) EncodeTuple(elements ...TupleElement) (out string, err error) {
+	c.buf = c.emptyBuf()
+	for _, e := range elements {
+		switch e.Kind {
+		case TupleNumber:
+			// the empty token holds no number, but it would sort before all numbers
+			if e.Value == "" {
+				return "", newSyntaxError(e.Value, 0, ReasonUnexpectedEnd)
+			}
+			c.buf = append(c.buf, tupleNumberTag)
+			if err := c.appendTokenBase(e.Value, maxBase); err != nil {
+				return "", err
+			}
+			c.buf = append(c.buf, tupleElementEnd)
+		case TupleText:
+			c.buf = append(c.buf, tupleTextTag)
+			c.appendEscaped(e.Value)
+		case TupleBytes:
+			c.buf = append(c.buf, tupleBytesTag)
+			c.appendEscaped(bytesToString(e.Bytes))
+		default:
+			return "", ErrKind
+		}
+	}
+	return string(c.buf), nil
+}
+
+// DecodeTuple turns a key created by EncodeTuple back into its elements. The numbers are restored
+// in the form DecodeToken produces. On failure it returns a *SyntaxError describing the problem.
+func (c *Codec) DecodeTuple(input string) (elements []TupleElement, err error) {
+	for pos := 0; pos < len(input); {
+		tag := input[pos]
+		pos++
+		switch tag {
+		case tupleNumberTag:
+			end := strings.IndexByte(input[pos:], tupleElementEnd)
+			if end < 0 {
+				return nil, newSyntaxError(input, len(input), ReasonUnexpectedEnd)
+			}
+			if end == 0 {
+				// EncodeTuple never writes an empty token
+				return nil, newSyntaxError(input, pos, ReasonUnexpectedEnd)
+			}
+			c.buf = c.emptyBuf()
+			if err := c.appendDecodedBase(input[pos:pos+end], maxBase); err != nil {
+				return nil, err.(*SyntaxError).shift(pos)
+			}
+			elements = append(elements, NumberElement(string(c.buf)))
+			pos += end + 1
+		case tupleTextTag, tupleBytesTag:
+			c.buf = c.emptyBuf()
+			pos, err = c.appendUnescaped(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			if tag == tupleTextTag {
+				elements = append(elements, TextElement(string(c.buf)))
+			} else {
+				elements = append(elements, BytesElement(append([]byte(nil), c.buf...)))
+			}
+		default:
+			return nil, newSyntaxError(input, pos-1, ReasonInvalidCharacter)
+		}
+	}
+	return elements, nil
+}
+
+// appendEscaped appends the content of a text or bytes element and its end marker to the buffer.
+func (c *Codec) appendEscaped(s string) {
+	c.grow(len(s) + 2)
+	for i := 0; i < len(s); i++ {
+		c.buf = append(c.buf, s[i])
+		if s[i] == tupleElementEnd {
+			c.buf = append(c.buf, tupleEscapedEnd)
+		}
+	}
+	c.buf = append(c.buf, tupleElementEnd, tupleTextEnd)
+}
+
+// appendUnescaped appends the content of the text or bytes element starting at pos to the buffer,
+// and returns the position following its end marker.
+func (c *Codec) appendUnescaped(input string, pos int) (next int, err error) {
+	for pos < len(input) {
+		if input[pos] != tupleElementEnd {
+			c.buf = append(c.buf, input[pos])
+			pos++
+			continue
+		}
+		if pos+1 == len(input) {
+			break
+		}
+		switch input[pos+1] {
+		case tupleEscapedEnd:
+			c.buf = append(c.buf, tupleElementEnd)
+		case tupleTextEnd:
+			return pos + 2, nil
+		default:
+			return 0, newSyntaxError(input, pos+1, ReasonInvalidCharacter)
+		}
+		pos += 2
+	}
+	return 0, newSyntaxError(input, len(input), ReasonUnexpectedEnd)
+}
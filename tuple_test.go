@@ -0,0 +1,160 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCodec_Tuple(t *testing.T) {
+	testCases := []struct {
+		name     string
+		elements []TupleElement
+		encoded  string
+		decoded  []TupleElement
+	}{
+		{name: "empty", elements: nil, encoded: "", decoded: nil},
+		{
+			name:     "number",
+			elements: []TupleElement{NumberElement("-001.50")},
+			encoded:  "\x043yyu~\x00",
+			decoded:  []TupleElement{NumberElement("-1.5")},
+		},
+		{
+			name:     "text",
+			elements: []TupleElement{TextElement("a b\x00c")},
+			encoded:  "\x05a b\x00\xffc\x00\x01",
+			decoded:  []TupleElement{TextElement("a b\x00c")},
+		},
+		{
+			name:     "bytes",
+			elements: []TupleElement{BytesElement([]byte{0, 1, 255})},
+			encoded:  "\x06\x00\xff\x01\xff\x00\x01",
+			decoded:  []TupleElement{BytesElement([]byte{0, 1, 255})},
+		},
+		{
+			name:     "mixed",
+			elements: []TupleElement{TextElement("acme"), NumberElement("10"), TextElement(""), BytesElement(nil)},
+			encoded:  "\x05acme\x00\x01\x04721\x00\x05\x00\x01\x06\x00\x01",
+			decoded:  []TupleElement{TextElement("acme"), NumberElement("10"), TextElement(""), BytesElement(nil)},
+		},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := c.EncodeTuple(tc.elements...)
+			if err != nil || encoded != tc.encoded {
+				t.Fatalf("EncodeTuple() = %q, %v, expected %q", encoded, err, tc.encoded)
+			}
+			decoded, err := c.DecodeTuple(encoded)
+			if err != nil || !reflect.DeepEqual(decoded, tc.decoded) {
+				t.Fatalf("DecodeTuple(%q) = %v, %v, expected %v", encoded, decoded, err, tc.decoded)
+			}
+		})
+	}
+}
+
+func TestCodec_Tuple_Failure(t *testing.T) {
+	c := new(Codec)
+
+	var syntaxErr *SyntaxError
+	if _, err := c.EncodeTuple(TextElement("x"), NumberElement("1.2.3")); !errors.As(err, &syntaxErr) || syntaxErr.Offset != 3 {
+		t.Errorf("EncodeTuple error = %v, expected a *SyntaxError at offset 3", err)
+	}
+	if _, err := c.EncodeTuple(NumberElement("")); !errors.As(err, &syntaxErr) || syntaxErr.Reason != ReasonUnexpectedEnd {
+		t.Errorf("EncodeTuple error = %v, expected %v", err, ReasonUnexpectedEnd)
+	}
+	if _, err := c.EncodeTuple(TupleElement{}); err != ErrKind {
+		t.Errorf("EncodeTuple error = %v, expected %v", err, ErrKind)
+	}
+
+	testCases := []struct {
+		name   string
+		input  string
+		reason SyntaxErrorReason
+		offset int
+	}{
+		{name: "unknown tag", input: "\x05a\x00\x01\x09", reason: ReasonInvalidCharacter, offset: 4},
+		{name: "unterminated number", input: "\x04711", reason: ReasonUnexpectedEnd, offset: 4},
+		{name: "empty number", input: "\x04\x00", reason: ReasonUnexpectedEnd, offset: 1},
+		{name: "empty number after text", input: "\x05a\x00\x01\x04\x00", reason: ReasonUnexpectedEnd, offset: 5},
+		{name: "invalid number", input: "\x05a\x00\x01\x043yy\x00", reason: ReasonMissingTerminator, offset: 7},
+		{name: "unterminated text", input: "\x05abc", reason: ReasonUnexpectedEnd, offset: 4},
+		{name: "unterminated escape", input: "\x05abc\x00", reason: ReasonUnexpectedEnd, offset: 5},
+		{name: "invalid escape", input: "\x05abc\x00\x02", reason: ReasonInvalidCharacter, offset: 5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.DecodeTuple(tc.input)
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("DecodeTuple(%q) error = %v, expected a *SyntaxError", tc.input, err)
+			}
+			if syntaxErr.Reason != tc.reason || syntaxErr.Offset != tc.offset {
+				t.Errorf("DecodeTuple(%q) error = %v, expected %v at offset %d", tc.input, err, tc.reason, tc.offset)
+			}
+		})
+	}
+}
+
+func TestTupleSortedness(t *testing.T) {
+	// the tuples are listed in their expected order
+	tuples := [][]TupleElement{
+		{},
+		{TextElement("")},
+		{TextElement(""), NumberElement("-1")},
+		{TextElement("\x00")},
+		{TextElement("\x00\x00")},
+		{TextElement("\x01")},
+		{TextElement(" ")},
+		{TextElement("a")},
+		{TextElement("a"), NumberElement("-10")},
+		{TextElement("a"), NumberElement("-9.5")},
+		{TextElement("a"), NumberElement("0")},
+		{TextElement("a"), NumberElement("2")},
+		{TextElement("a"), NumberElement("2"), TextElement("z")},
+		{TextElement("a"), NumberElement("2.5")},
+		{TextElement("a"), NumberElement("10")},
+		{TextElement("a"), TextElement("")},
+		{TextElement("a"), BytesElement(nil)},
+		{TextElement("a"), BytesElement([]byte{0})},
+		{TextElement("a\x00")},
+		{TextElement("a\x00b")},
+		{TextElement("a b")},
+		{TextElement("a b"), NumberElement("1")},
+		{TextElement("ab")},
+		{BytesElement([]byte{255})},
+	}
+
+	c := new(Codec)
+	prev := ""
+	for i, tuple := range tuples {
+		encoded, err := c.EncodeTuple(tuple...)
+		if err != nil {
+			t.Fatal("Encoding failed for", tuple)
+		}
+		if i > 0 && prev >= encoded {
+			t.Fatalf("tuple %d %v: %q is not smaller than %q", i, tuple, prev, encoded)
+		}
+		prev = encoded
+	}
+}
+
+func ExampleCodec_EncodeTuple() {
+	c := new(Codec)
+
+	key, _ := c.EncodeTuple(TextElement("acme"), NumberElement("19.99"), TextElement("red shoes"))
+	fmt.Printf("%q\n", key)
+
+	elements, _ := c.DecodeTuple(key)
+	for _, e := range elements {
+		fmt.Printf("%q\n", e.Value)
+	}
+
+	// Output:
+	// "\x05acme\x00\x01\x04721999\x00\x05red shoes\x00\x01"
+	// "acme"
+	// "19.99"
+	// "red shoes"
+}
@@ -0,0 +1,222 @@
+package conust
+
+import (
+	"math"
+	"strings"
+)
+
+// The tags of the values without content sort before the tags shared with the tuple elements,
+// giving the order null < false < true < numbers < strings < bytes.
+const (
+	valueNullTag  byte = 0x01
+	valueFalseTag byte = 0x02
+	valueTrueTag  byte = 0x03
+)
+
+// The number kinds follow the token of a number value, so that its original type can be restored.
+// Equal numbers of different types are ordered by them.
+const (
+	valueIntKind byte = iota + 1
+	valueInt8Kind
+	valueInt16Kind
+	valueInt32Kind
+	valueInt64Kind
+	valueUintKind
+	valueUint8Kind
+	valueUint16Kind
+	valueUint32Kind
+	valueUint64Kind
+	valueFloat32Kind
+	valueFloat64Kind
+)
+
+// EncodeValue encodes a value of one of the supported Go types into a key, so that values of different
+// types can be stored in the same column and still sort deterministically: nil < false < true < numbers
+// < strings < byte slices. Numbers of all types are ordered by their value, and equal numbers by their type.
+// Float32 values are stored with the digits of their float64 conversion, so float32(0.1) sorts after 0.1,
+// since it is a larger number.
+// The supported types are the built-in integer and float types, bool, string, []byte and nil.
+// Strings and byte slices are encoded the same way as the text and bytes elements of EncodeTuple,
+// the output is binary. ErrKind is returned for other types, and ErrRange for NaN and infinite floats.
+func (c *Codec) EncodeValue(v interface{}) (out string, err error) {
+	var token string
+	var kind byte
+	ok := true
+	switch v := v.(type) {
+	case nil:
+		return string(valueNullTag), nil
+	case bool:
+		if v {
+			return string(valueTrueTag), nil
+		}
+		return string(valueFalseTag), nil
+	case string:
+		c.buf = append(c.emptyBuf(), tupleTextTag)
+		c.appendEscaped(v)
+		return string(c.buf), nil
+	case []byte:
+		c.buf = append(c.emptyBuf(), tupleBytesTag)
+		c.appendEscaped(bytesToString(v))
+		return string(c.buf), nil
+	case int:
+		token = c.EncodeInt64(int64(v))
+		kind = valueIntKind
+	case int8:
+		token = c.EncodeInt64(int64(v))
+		kind = valueInt8Kind
+	case int16:
+		token = c.EncodeInt64(int64(v))
+		kind = valueInt16Kind
+	case int32:
+		token = c.EncodeInt64(int64(v))
+		kind = valueInt32Kind
+	case int64:
+		token = c.EncodeInt64(v)
+		kind = valueInt64Kind
+	case uint:
+		token = c.EncodeUint64(uint64(v))
+		kind = valueUintKind
+	case uint8:
+		token = c.EncodeUint64(uint64(v))
+		kind = valueUint8Kind
+	case uint16:
+		token = c.EncodeUint64(uint64(v))
+		kind = valueUint16Kind
+	case uint32:
+		token = c.EncodeUint64(uint64(v))
+		kind = valueUint32Kind
+	case uint64:
+		token = c.EncodeUint64(v)
+		kind = valueUint64Kind
+	case float32:
+		// the digits of the float64 conversion keep the float32 values ordered among the float64 values
+		token, ok = c.encodeFloat(float64(v), 64)
+		kind = valueFloat32Kind
+	case float64:
+		token, ok = c.encodeFloat(v, 64)
+		kind = valueFloat64Kind
+	default:
+		return "", ErrKind
+	}
+	if !ok {
+		return "", ErrRange
+	}
+
+	// the kind of the number follows the token, so that the original type can be restored
+	c.buf = append(c.emptyBuf(), tupleNumberTag)
+	c.buf = append(c.buf, token...)
+	c.buf = append(c.buf, tupleElementEnd, kind)
+	return string(c.buf), nil
+}
+
+// DecodeValue turns a key created by EncodeValue back into a value of its original type.
+// On failure it returns a *SyntaxError describing the problem, or the error of Decode if the number
+// does not fit into the type it is tagged with.
+func (c *Codec) DecodeValue(input string) (v interface{}, err error) {
+	if input == "" {
+		return nil, newSyntaxError(input, 0, ReasonUnexpectedEnd)
+	}
+
+	var next int
+	switch input[0] {
+	case valueNullTag:
+		v, next = nil, 1
+	case valueFalseTag:
+		v, next = false, 1
+	case valueTrueTag:
+		v, next = true, 1
+	case tupleTextTag, tupleBytesTag:
+		c.buf = c.emptyBuf()
+		next, err = c.appendUnescaped(input, 1)
+		if err != nil {
+			return nil, err
+		}
+		if input[0] == tupleTextTag {
+			v = string(c.buf)
+		} else {
+			v = append([]byte{}, c.buf...)
+		}
+	case tupleNumberTag:
+		v, next, err = c.decodeValueNumber(input)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, newSyntaxError(input, 0, ReasonInvalidCharacter)
+	}
+
+	if next < len(input) {
+		return nil, newSyntaxError(input, next, ReasonInvalidCharacter)
+	}
+	return v, nil
+}
+
+func (c *Codec) decodeValueNumber(input string) (v interface{}, next int, err error) {
+	end := strings.IndexByte(input, tupleElementEnd)
+	if end < 0 || end+1 == len(input) {
+		return nil, 0, newSyntaxError(input, len(input), ReasonUnexpectedEnd)
+	}
+	token := input[1:end]
+	if token != zeroOutput {
+		if _, err := c.parseToken(token); err != nil {
+			return nil, 0, err.(*SyntaxError).shift(1)
+		}
+	}
+
+	switch input[end+1] {
+	case valueIntKind:
+		v, err = decodeValue[int](c, token)
+	case valueInt8Kind:
+		v, err = decodeValue[int8](c, token)
+	case valueInt16Kind:
+		v, err = decodeValue[int16](c, token)
+	case valueInt32Kind:
+		v, err = decodeValue[int32](c, token)
+	case valueInt64Kind:
+		v, err = decodeValue[int64](c, token)
+	case valueUintKind:
+		v, err = decodeValue[uint](c, token)
+	case valueUint8Kind:
+		v, err = decodeValue[uint8](c, token)
+	case valueUint16Kind:
+		v, err = decodeValue[uint16](c, token)
+	case valueUint32Kind:
+		v, err = decodeValue[uint32](c, token)
+	case valueUint64Kind:
+		v, err = decodeValue[uint64](c, token)
+	case valueFloat32Kind:
+		v, err = c.decodeFloat32Value(token)
+	case valueFloat64Kind:
+		v, err = decodeValue[float64](c, token)
+	default:
+		return nil, 0, newSyntaxError(input, end+1, ReasonInvalidCharacter)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return v, end + 2, nil
+}
+
+// decodeValue decodes the token into a T wrapped into an interface, so that a failure results in a nil value.
+func decodeValue[T Number](c *Codec, token string) (interface{}, error) {
+	v, err := decodeNumber[T](c, token)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// decodeFloat32Value decodes the token of a float32 value, which holds the digits of its float64 conversion.
+func (c *Codec) decodeFloat32Value(token string) (interface{}, error) {
+	v, err := decodeNumber[float64](c, token)
+	if err != nil {
+		return nil, err
+	}
+	if math.Abs(v) > math.MaxFloat32 {
+		return nil, ErrRange
+	}
+	if float64(float32(v)) != v {
+		return nil, ErrPrecision
+	}
+	return float32(v), nil
+}
@@ -0,0 +1,173 @@
+package conust
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodec_Value(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   interface{}
+		encoded string
+	}{
+		{name: "nil", value: nil, encoded: "\x01"},
+		{name: "false", value: false, encoded: "\x02"},
+		{name: "true", value: true, encoded: "\x03"},
+		{name: "int", value: -15, encoded: "\x043xyu~\x00\x01"},
+		{name: "int8", value: int8(math.MinInt8), encoded: "\x043wyxr~\x00\x02"},
+		{name: "int16", value: int16(0), encoded: "\x045\x00\x03"},
+		{name: "int32", value: int32(7), encoded: "\x04717\x00\x04"},
+		{name: "int64", value: int64(math.MaxInt64), encoded: "\x047j9223372036854775807\x00\x05"},
+		{name: "uint", value: uint(12), encoded: "\x047212\x00\x06"},
+		{name: "uint8", value: uint8(255), encoded: "\x0473255\x00\x07"},
+		{name: "uint16", value: uint16(1000), encoded: "\x04741\x00\x08"},
+		{name: "uint32", value: uint32(1), encoded: "\x04711\x00\x09"},
+		{name: "uint64", value: uint64(math.MaxUint64), encoded: "\x047k18446744073709551615\x00\x0a"},
+		{name: "float32", value: float32(0.1), encoded: "\x046z10000000149011612\x00\x0b"},
+		{name: "float64", value: -2.5, encoded: "\x043yxu~\x00\x0c"},
+		{name: "string", value: "a\x00b", encoded: "\x05a\x00\xffb\x00\x01"},
+		{name: "empty string", value: "", encoded: "\x05\x00\x01"},
+		{name: "bytes", value: []byte{1, 2}, encoded: "\x06\x01\x02\x00\x01"},
+		{name: "empty bytes", value: []byte{}, encoded: "\x06\x00\x01"},
+	}
+
+	c := new(Codec)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := c.EncodeValue(tc.value)
+			if err != nil || encoded != tc.encoded {
+				t.Fatalf("EncodeValue(%#v) = %q, %v, expected %q", tc.value, encoded, err, tc.encoded)
+			}
+			decoded, err := c.DecodeValue(encoded)
+			if err != nil || !reflect.DeepEqual(decoded, tc.value) {
+				t.Fatalf("DecodeValue(%q) = %#v, %v, expected %#v", encoded, decoded, err, tc.value)
+			}
+		})
+	}
+}
+
+func TestCodec_Value_Failure(t *testing.T) {
+	c := new(Codec)
+
+	if _, err := c.EncodeValue(struct{}{}); err != ErrKind {
+		t.Errorf("EncodeValue of a struct error = %v, expected %v", err, ErrKind)
+	}
+	if _, err := c.EncodeValue(math.NaN()); err != ErrRange {
+		t.Errorf("EncodeValue of NaN error = %v, expected %v", err, ErrRange)
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "empty", input: "", err: ErrSyntax},
+		{name: "unknown tag", input: "\x09", err: ErrSyntax},
+		{name: "trailing bytes", input: "\x03\x03", err: ErrSyntax},
+		{name: "unterminated number", input: "\x04711", err: ErrSyntax},
+		{name: "missing number kind", input: "\x04711\x00", err: ErrSyntax},
+		{name: "unknown number kind", input: "\x04711\x00\x19", err: ErrSyntax},
+		{name: "invalid token", input: "\x043yy\x00\x01", err: ErrSyntax},
+		{name: "out of range", input: "\x04741\x00\x02", err: ErrRange},
+		{name: "fraction of integer", input: "\x043yxu~\x00\x01", err: ErrFraction},
+		{name: "float32 out of range", input: "\x047zzzzzzzzz11\x00\x0b", err: ErrRange},
+		{name: "float32 not exact", input: "\x046z1\x00\x0b", err: ErrPrecision},
+		{name: "unterminated string", input: "\x05abc", err: ErrSyntax},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := c.DecodeValue(tc.input); !errors.Is(err, tc.err) {
+				t.Errorf("DecodeValue(%q) error = %v, expected %v", tc.input, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCodec_Value_Terminator(t *testing.T) {
+	c, err := NewCodec(WithTerminator('}'))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []interface{}{-5, int8(-128), -2.5, -0.1, float32(-2.5), float32(-0.1)} {
+		encoded, err := c.EncodeValue(value)
+		if err != nil || !strings.Contains(encoded, "}") {
+			t.Fatalf("EncodeValue(%#v) = %q, %v, expected a token with the custom terminator", value, encoded, err)
+		}
+		decoded, err := c.DecodeValue(encoded)
+		if err != nil || !reflect.DeepEqual(decoded, value) {
+			t.Errorf("DecodeValue(%q) = %#v, %v, expected %#v", encoded, decoded, err, value)
+		}
+	}
+}
+
+func TestValueSortedness(t *testing.T) {
+	// the values are listed in their expected order
+	values := []interface{}{
+		nil,
+		false,
+		true,
+		-math.MaxFloat64,
+		-1e300,
+		int64(math.MinInt64),
+		-2.5,
+		-2,
+		int8(-2),
+		-0.5,
+		0,
+		uint(0),
+		0.1,
+		float32(0.1),
+		1,
+		uint8(1),
+		float32(1.5),
+		1.5,
+		int16(1000),
+		uint64(math.MaxUint64),
+		1e300,
+		"",
+		"\x00",
+		"a",
+		"a\x00",
+		"a b",
+		"ab",
+		[]byte{},
+		[]byte{0},
+		[]byte{0xff},
+	}
+
+	c := new(Codec)
+	prev := ""
+	for i, value := range values {
+		encoded, err := c.EncodeValue(value)
+		if err != nil {
+			t.Fatalf("Encoding failed for %#v: %v", value, err)
+		}
+		if i > 0 && prev >= encoded {
+			t.Fatalf("value %d %#v: %q is not smaller than %q", i, value, prev, encoded)
+		}
+		prev = encoded
+	}
+}
+
+func ExampleCodec_EncodeValue() {
+	c := new(Codec)
+
+	for _, value := range []interface{}{nil, true, 42, 3.5, "text"} {
+		key, _ := c.EncodeValue(value)
+		decoded, _ := c.DecodeValue(key)
+		fmt.Printf("%q %T\n", key, decoded)
+	}
+
+	// Output:
+	// "\x01" <nil>
+	// "\x03" bool
+	// "\x047242\x00\x01" int
+	// "\x047135\x00\f" float64
+	// "\x05text\x00\x01" string
+}